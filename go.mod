@@ -7,6 +7,8 @@ require (
 	github.com/GrandOichii/box v0.0.0-20220203103332-865d3af98c3f
 	github.com/GrandOichii/colorwrapper v0.0.0-20220203103117-b874d1231741
 	github.com/go-rod/rod v0.103.0
+	go.etcd.io/bbolt v1.3.7
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -16,5 +18,5 @@ require (
 	github.com/ysmood/goob v0.3.1 // indirect
 	github.com/ysmood/gson v0.6.4 // indirect
 	github.com/ysmood/leakless v0.7.0 // indirect
-	golang.org/x/sys v0.0.0-20210630005230-0f9fa26af87c // indirect
+	golang.org/x/sys v0.4.0 // indirect
 )
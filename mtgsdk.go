@@ -1,26 +1,27 @@
 package mtgsdk
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net"
-	"net/http"
 	"net/url"
+	"strings"
 	"sync"
-
-	"github.com/GrandOichii/appdata"
 )
 
 const (
-	imageFileFormat    = "jpg"                       // the format for image files
-	allCardsFileName   = "all_cards.json"            // the path to to the all_cards json file
-	edhrecDataFile     = "edhrec_data.json"          // the path to the file with all the edhrec data for commanders
-	edhrecStaplesFile  = "edhrec_staples.json"       // The path to the file with all the ids of staple cards for commander
-	imagesFolder       = "images"                    // the folder for the images
-	apiURL             = "https://api.scryfall.com/" // the url of the api for fetching card data
-	cardIDSearchURL    = apiURL + "/cards/"          // the url for searching for cards
-	cardQuerySearchURL = apiURL + "/cards/search?q=" // the query url
+	imageFileFormat   = "jpg"                 // the format for image files
+	allCardsFileName  = "all_cards.json"      // the path to to the all_cards json file (used by the json card store, imported into the bbolt store)
+	edhrecDataFile    = "edhrec_data.json"    // the path to the file with all the edhrec data for commanders
+	edhrecStaplesFile = "edhrec_staples.json" // The path to the file with all the ids of staple cards for commander
+	imagesFolder      = "images"              // the folder for the images
+	decksFolder       = "decks"               // the folder for the saved decks and their revisions
+	decksIndexFile    = "decks/index.json"    // the path to the index of the saved decks
+
+	imageDownloadWorkers = 8 // the maximum amount of concurrent image downloads
 
 	cardPrintWidth  = 40 // width of the card (for terminal)
 	cardPrintHeight = 25 // height of the card (for terminal)
@@ -39,10 +40,6 @@ const (
 )
 
 var (
-	adm          appdata.AppDataManager    // the app data manager
-	allCardsDict map[string]Card           // the map of all cards
-	edhrecData   map[string]map[string]int // the map of all commanders and their reccomendations (card.id -- synergy)
-
 	colorMap = map[string]string{ // the map of the colors used to print cards
 		"W":    "hiwhite",
 		"U":    "cyan",
@@ -54,119 +51,119 @@ var (
 	}
 )
 
-func init() {
-	var err error
-	adm, err = appdata.CreateAppDataManager("mtgsdk-data")
-	if err != nil {
-		panic(err)
-	}
-	// create allCards file
-	err = createAllCardsFile()
-	if err != nil {
-		panic(err)
+// Opens the card store (unless it was specified in the options)
+//
+// The first time the bbolt database is created, the cards of the old json cache are imported into it
+func (c *Client) openCardStore() error {
+	if c.store != nil {
+		return nil
 	}
-	// create the edhrec data file
-	err = createEDHRECFiles()
-	if err != nil {
-		panic(err)
+	var err error
+	if c.useJSONStore {
+		c.store, err = OpenJSONCardStore(c.adm.ConcatPath(allCardsFileName))
+		return err
 	}
-	// create imagesFolder folder
-	err = createCardImagesFolder()
+	existed, err := c.adm.FileExists(boltCardsFileName)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	// load all cards
-	err = loadAllCards()
+	store, err := OpenBoltCardStore(c.adm.ConcatPath(boltCardsFileName))
 	if err != nil {
-		panic(err)
+		return err
 	}
-	// load edhrec data
-	err = loadEDHRECData()
-	if err != nil {
-		panic(err)
+	if !existed {
+		err = c.migrateJSONCards(store)
+		if err != nil {
+			// remove the new database, so that the migration is retried
+			store.Close()
+			c.adm.RemoveFile(boltCardsFileName)
+			return err
+		}
 	}
+	c.store = store
+	return nil
 }
 
-// Loads all the cards from the all_cards json file
-func loadAllCards() error {
-	// read the data
-	existingData, err := adm.ReadFile(allCardsFileName)
-	if err != nil {
+// Imports the cards of the json cache (if it exists) into the store
+func (c *Client) migrateJSONCards(store CardStore) error {
+	exists, err := c.adm.FileExists(allCardsFileName)
+	if err != nil || !exists {
 		return err
 	}
-	// parse the data
-	err = json.Unmarshal(existingData, &allCardsDict)
+	data, err := c.adm.ReadFile(allCardsFileName)
 	if err != nil {
 		return err
 	}
-	return nil
-}
-
-// Loads the edhrec data
-func loadEDHRECData() error {
-	existsingData, err := adm.ReadFile(edhrecDataFile)
+	cards := map[string]Card{}
+	err = json.Unmarshal(data, &cards)
 	if err != nil {
-		return err
+		return fmt.Errorf("mtgsdk - can't read the json card cache: %w", err)
 	}
-	// parse the data
-	err = json.Unmarshal(existsingData, &edhrecData)
+	batch := make([]Card, 0, storeBatchSize)
+	for _, card := range cards {
+		batch = append(batch, card)
+		if len(batch) == storeBatchSize {
+			err = store.PutBatch(batch)
+			if err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	err = store.PutBatch(batch)
 	if err != nil {
 		return err
 	}
+	log.Printf("mtgsdk - imported %d cards from %s into the card database", len(cards), allCardsFileName)
 	return nil
 }
 
-// Saves the local cards into the all_cards json file
-func saveLocalCardDict() error {
-	data, err := json.MarshalIndent(allCardsDict, "", "\t")
+// Loads the edhrec data
+func (c *Client) loadEDHRECData() error {
+	existsingData, err := c.adm.ReadFile(edhrecDataFile)
 	if err != nil {
 		return err
 	}
-	return adm.WriteToFile(allCardsFileName, data)
-}
-
-// Saves the edhrec data locally
-func saveEDHRECData() error {
-	data, err := json.MarshalIndent(edhrecData, "", "\t")
+	// parse the data
+	err = json.Unmarshal(existsingData, &c.edhrecData)
 	if err != nil {
 		return err
 	}
-	return adm.WriteToFile(edhrecDataFile, data)
+	return nil
 }
 
-// Creates the all_cards json file
-func createAllCardsFile() error {
-	exists, err := adm.FileExists(allCardsFileName)
+// Saves the edhrec data locally
+func (c *Client) saveEDHRECData() error {
+	// the snapshot and the write happen under the same lock, so an older snapshot can't overwrite a newer one
+	c.edhrecSaveMu.Lock()
+	defer c.edhrecSaveMu.Unlock()
+	c.edhrecMu.RLock()
+	data, err := json.MarshalIndent(c.edhrecData, "", "\t")
+	c.edhrecMu.RUnlock()
 	if err != nil {
 		return err
 	}
-	if !exists {
-		err = adm.WriteToFile(allCardsFileName, []byte("{}"))
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return c.adm.WriteToFile(edhrecDataFile, data)
 }
 
 // Create the edhrec data json file
-func createEDHRECFiles() error {
-	exists, err := adm.FileExists(edhrecDataFile)
+func (c *Client) createEDHRECFiles() error {
+	exists, err := c.adm.FileExists(edhrecDataFile)
 	if err != nil {
 		return err
 	}
 	if !exists {
-		err = adm.WriteToFile(edhrecDataFile, []byte("{}"))
+		err = c.adm.WriteToFile(edhrecDataFile, []byte("{}"))
 		if err != nil {
 			return err
 		}
 	}
-	exists, err = adm.FileExists(edhrecStaplesFile)
+	exists, err = c.adm.FileExists(edhrecStaplesFile)
 	if err != nil {
 		return err
 	}
 	if !exists {
-		err = adm.WriteToFile(edhrecStaplesFile, []byte("[]"))
+		err = c.adm.WriteToFile(edhrecStaplesFile, []byte("[]"))
 		if err != nil {
 			return err
 		}
@@ -175,13 +172,13 @@ func createEDHRECFiles() error {
 }
 
 // Creates the card images folder
-func createCardImagesFolder() error {
-	exists, err := adm.FileExists(imagesFolder)
+func (c *Client) createCardImagesFolder() error {
+	exists, err := c.adm.FileExists(imagesFolder)
 	if err != nil {
 		return err
 	}
 	if !exists {
-		err = adm.CreateFolder(imagesFolder)
+		err = c.adm.CreateFolder(imagesFolder)
 		if err != nil {
 			return err
 		}
@@ -189,48 +186,104 @@ func createCardImagesFolder() error {
 	return nil
 }
 
-// Saves card to allCards
+// Saves card to the card store
 //
-// First checks whether card is already in dict. If true, doesn't do anything. If false, adds the card to the dict and saves the dict to allCardsPath
-func saveCard(card Card) error {
+// First checks whether card is already in the store. If true, doesn't do anything. If false, adds the card to the store
+func (c *Client) saveCard(card Card) error {
 	// if card has no id, don't do anything
 	if card.ID == "" {
 		log.Printf("mtgsdk - fetched card with name %v, but it doesn't have an id", card.Name)
 		return nil
 	}
-	// add card to dict
-	// if key is already in dictionary
-	if _, hasid := allCardsDict[card.ID]; hasid {
+	// if card is already in the store
+	_, has, err := c.store.Get(card.ID)
+	if err != nil {
+		return err
+	}
+	if has {
 		return nil
 	}
-	allCardsDict[card.ID] = card
-	// save dict to file
-	err := saveLocalCardDict()
+	err = c.store.Put(card)
 	if err != nil {
 		return err
 	}
-	log.Printf("mtgsdk - added card %v to all cards file", card.ID)
+	log.Printf("mtgsdk - added card %v to the card store", card.ID)
 	return nil
 }
 
-// Parses params to query escaped string
-func paramsToQ(params map[string]string) string {
-	result := ""
-	for key, value := range params {
-		if value != "" {
-			result += url.QueryEscape(key+"="+value) + "+"
+// Saves the cards to the card store in a single batch
+//
+// Replaces the cards that are already in the store with the fresh versions
+func (c *Client) saveCards(cards []Card) error {
+	batch := make([]Card, 0, len(cards))
+	for _, card := range cards {
+		if card.ID == "" {
+			log.Printf("mtgsdk - fetched card with name %v, but it doesn't have an id", card.Name)
+			continue
 		}
+		batch = append(batch, card)
 	}
-	log.Printf("mtgsdk - parsed %v to %v", params, result)
-	return result
+	return c.store.PutBatch(batch)
 }
 
 // Fetches the cards from the scryfall api
-func FetchCards(params map[string]string) ([]Card, error) {
-	log.Printf("mtgsdk - fetching cards with params %v", params)
-	parsed := paramsToQ(params)
-	url := cardQuerySearchURL + parsed
-	resp, err := http.Get(url)
+//
+// Follows all the result pages of the search
+func (c *Client) FetchCards(params map[string]string) ([]Card, error) {
+	return c.FetchCardsContext(context.Background(), params)
+}
+
+// Same as FetchCards, but uses the context for the network requests
+func (c *Client) FetchCardsContext(ctx context.Context, params map[string]string) ([]Card, error) {
+	return c.FetchCardsLimitContext(ctx, params, 0)
+}
+
+// Fetches at most limit cards from the scryfall api
+//
+// If limit is not positive, fetches all the cards
+func (c *Client) FetchCardsLimit(params map[string]string, limit int) ([]Card, error) {
+	return c.FetchCardsLimitContext(context.Background(), params, limit)
+}
+
+// Same as FetchCardsLimit, but uses the context for the network requests
+func (c *Client) FetchCardsLimitContext(ctx context.Context, params map[string]string, limit int) ([]Card, error) {
+	return c.FetchQueryLimitContext(ctx, paramsToQuery(params), limit)
+}
+
+// Fetches the cards that match the query from the scryfall api
+func (c *Client) FetchQuery(q QueryNode) ([]Card, error) {
+	return c.FetchQueryContext(context.Background(), q)
+}
+
+// Same as FetchQuery, but uses the context for the network requests
+func (c *Client) FetchQueryContext(ctx context.Context, q QueryNode) ([]Card, error) {
+	return c.FetchQueryLimitContext(ctx, q, 0)
+}
+
+// Fetches at most limit cards that match the query from the scryfall api
+//
+// If limit is not positive, fetches all the cards
+func (c *Client) FetchQueryLimit(q QueryNode, limit int) ([]Card, error) {
+	return c.FetchQueryLimitContext(context.Background(), q, limit)
+}
+
+// Same as FetchQueryLimit, but uses the context for the network requests
+func (c *Client) FetchQueryLimitContext(ctx context.Context, q QueryNode, limit int) ([]Card, error) {
+	result := []Card{}
+	it := c.IterQueryContext(ctx, q, limit)
+	for it.Next() {
+		result = append(result, it.Card())
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	log.Printf("mtgsdk - fetched %v cards", len(result))
+	return result, nil
+}
+
+// Fetches a single page of the card search
+func (c *Client) fetchCardPage(ctx context.Context, url string) (cardPage, error) {
+	resp, err := c.get(ctx, url)
 	//  can't connect to host
 	// var dnsError *net.DNSError
 	// if errors.As(err, &dnsError) {
@@ -238,48 +291,68 @@ func FetchCards(params map[string]string) ([]Card, error) {
 	// 	return getCardsOffline(params)
 	// }
 	if err != nil {
-		return nil, err
+		return cardPage{}, err
 	}
 	defer resp.Body.Close()
 	// managed to fetch data
-	var cardc struct {
-		Cards []Card `json:"data"`
-	}
-	err = json.NewDecoder(resp.Body).Decode(&cardc)
+	var page cardPage
+	err = json.NewDecoder(resp.Body).Decode(&page)
 	if err != nil {
-		return nil, err
+		return cardPage{}, err
 	}
-	log.Printf("mtgsdk - fetched %v cards", len(cardc.Cards))
-	// save all cards to all cards file
-	for _, card := range cardc.Cards {
-		err = saveCard(card)
-		if err != nil {
-			return nil, err
-		}
+	// save all cards to the card store
+	err = c.saveCards(page.Data)
+	if err != nil {
+		return cardPage{}, err
 	}
-	return cardc.Cards, nil
+	return page, nil
+}
+
+// Returns the cards stored in the card store
+func (c *Client) GetCardsOffline(params map[string]string) ([]Card, error) {
+	return c.GetCardsOfflineQuery(paramsToQuery(params))
 }
 
-// Returns the cards stored in the all_cards json file
-func GetCardsOffline(params map[string]string) ([]Card, error) {
-	cards := make([]Card, 0, len(allCardsDict))
-	for _, card := range allCardsDict {
-		cards = append(cards, card)
+// Returns the cards stored in the card store that match the query
+func (c *Client) GetCardsOfflineQuery(q QueryNode) ([]Card, error) {
+	result := []Card{}
+	err := c.store.Each(func(card Card) bool {
+		if q.Match(card) {
+			result = append(result, card)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
-	result := applyQ(cards, params)
 	return result, nil
 }
 
 // Searches the cards online, if fails, searches for them locally
-func GetCards(params map[string]string, offline bool) ([]Card, error) {
+func (c *Client) GetCards(params map[string]string, offline bool) ([]Card, error) {
+	return c.GetCardsContext(context.Background(), params, offline)
+}
+
+// Same as GetCards, but uses the context for the network requests
+func (c *Client) GetCardsContext(ctx context.Context, params map[string]string, offline bool) ([]Card, error) {
+	return c.GetCardsQueryContext(ctx, paramsToQuery(params), offline)
+}
+
+// Searches the cards that match the query online, if fails, searches for them locally
+func (c *Client) GetCardsQuery(q QueryNode, offline bool) ([]Card, error) {
+	return c.GetCardsQueryContext(context.Background(), q, offline)
+}
+
+// Same as GetCardsQuery, but uses the context for the network requests
+func (c *Client) GetCardsQueryContext(ctx context.Context, q QueryNode, offline bool) ([]Card, error) {
 	if offline {
-		return GetCardsOffline(params)
+		return c.GetCardsOfflineQuery(q)
 	}
-	cards, err := FetchCards(params)
+	cards, err := c.FetchQueryContext(ctx, q)
 	var dnsError *net.DNSError
 	if errors.As(err, &dnsError) {
-		log.Println("mtgsdk - failed to connect to host, looking up cards in allCardsPath")
-		return GetCardsOffline(params)
+		log.Println("mtgsdk - failed to connect to host, looking up cards in the card store")
+		return c.GetCardsOfflineQuery(q)
 	}
 	// some other kind of error
 	if err != nil {
@@ -290,9 +363,14 @@ func GetCards(params map[string]string, offline bool) ([]Card, error) {
 
 // Returns a slice of all cards that specify the params
 func applyQ(cards []Card, params map[string]string) []Card {
+	return applyQuery(cards, paramsToQuery(params))
+}
+
+// Returns a slice of all cards that match the query
+func applyQuery(cards []Card, q QueryNode) []Card {
 	result := make([]Card, 0, len(cards))
 	for _, card := range cards {
-		if card.Matches(params) {
+		if q.Match(card) {
 			result = append(result, card)
 		}
 	}
@@ -302,43 +380,64 @@ func applyQ(cards []Card, params map[string]string) []Card {
 // Downloads the card images that match the params
 //
 // If deckPath is not empty, selects the cards from the deckPath
-func DownloadCardImages(params map[string]string, deckPath string, outPath string, quality ImageQuality, offline bool) error {
+func (c *Client) DownloadCardImages(params map[string]string, deckPath string, outPath string, quality ImageQuality, offline bool) error {
+	return c.DownloadCardImagesContext(context.Background(), params, deckPath, outPath, quality, offline)
+}
+
+// Same as DownloadCardImages, but uses the context for the network requests
+func (c *Client) DownloadCardImagesContext(ctx context.Context, params map[string]string, deckPath string, outPath string, quality ImageQuality, offline bool) error {
 	var cards []Card
 	var err error
 	if deckPath == "" {
-		cards, err = GetCards(params, offline)
+		cards, err = c.GetCardsContext(ctx, params, offline)
 	} else {
-		deck, err := ReadDeck(deckPath)
-		deckCards := deck.GetUniqueCards()
+		deck, err := c.ReadDeckContext(ctx, deckPath)
 		if err != nil {
 			return err
 		}
-		cards = applyQ(deckCards, params)
+		cards = applyQ(deck.allCards(), params)
 	}
 	if err != nil {
 		return err
 	}
-	// create a wait group
+	// download the images with a limited amount of workers
+	jobs := make(chan Card)
+	errs := make(chan error, len(cards))
 	wg := sync.WaitGroup{}
-	wg.Add(len(cards))
-	for _, card := range cards {
-		c := card
+	for i := 0; i < imageDownloadWorkers; i++ {
+		wg.Add(1)
 		go func() {
-			err = c.DownloadImage(outPath, quality)
-			wg.Done()
+			defer wg.Done()
+			for card := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				err := c.DownloadImageContext(ctx, card, outPath, quality)
+				if err != nil {
+					errs <- fmt.Errorf("mtgsdk - failed to download image for %s: %w", card.Name, err)
+				}
+			}
 		}()
 	}
+	for _, card := range cards {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- card
+	}
+	close(jobs)
 	wg.Wait()
-	if err != nil {
-		return err
+	close(errs)
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
-	return nil
+	return joinErrors(collectErrors(errs)...)
 }
 
 // Fetches for the card with the specified id online
-func fetchCardWithID(id string) (Card, error) {
-	url := cardIDSearchURL + id
-	resp, err := http.Get(url)
+func (c *Client) fetchCardWithID(ctx context.Context, id string) (Card, error) {
+	url := c.apiURL + cardIDSearchPath + id
+	resp, err := c.get(ctx, url)
 	// 	// don't know whether to check for a connection error
 	if err != nil {
 		return Card{}, err
@@ -349,34 +448,109 @@ func fetchCardWithID(id string) (Card, error) {
 	if err != nil {
 		return Card{}, err
 	}
+	if card.ID == "" {
+		return Card{}, fmt.Errorf("mtgsdk - fetched card for id %s, but it doesn't have an id", id)
+	}
 	// save card
-	saveCard(card)
+	err = c.saveCard(card)
+	if err != nil {
+		return Card{}, err
+	}
 	return card, nil
 }
 
-// Checks if the id is in the allCardsDict, if not, searches for it online
-func GetCard(id string) (Card, error) {
-	if card, found := allCardsDict[id]; found {
+// Checks if the id is in the card store, if not, searches for it online
+func (c *Client) GetCard(id string) (Card, error) {
+	return c.GetCardContext(context.Background(), id)
+}
+
+// Same as GetCard, but uses the context for the network requests
+func (c *Client) GetCardContext(ctx context.Context, id string) (Card, error) {
+	card, found, err := c.store.Get(id)
+	if err != nil {
+		return Card{}, err
+	}
+	if found {
 		return card, nil
 	}
 	// failed to fetch locally, going online
-	card, err := fetchCardWithID(id)
+	card, err = c.fetchCardWithID(ctx, id)
 	if err != nil {
 		return Card{}, err
 	}
 	return card, nil
 }
 
+// Returns the card with the set code and the collector number
+//
+// If offline is true (or can't connect to scryfall), looks up the card in the card store
+func (c *Client) GetCardByPrinting(setCode string, collectorNumber string, offline bool) (Card, error) {
+	return c.GetCardByPrintingContext(context.Background(), setCode, collectorNumber, offline)
+}
+
+// Same as GetCardByPrinting, but uses the context for the network requests
+func (c *Client) GetCardByPrintingContext(ctx context.Context, setCode string, collectorNumber string, offline bool) (Card, error) {
+	if offline {
+		return c.getCardByPrintingOffline(setCode, collectorNumber)
+	}
+	printingURL := c.apiURL + fmt.Sprintf(cardPrintingPath, url.PathEscape(strings.ToLower(setCode)), url.PathEscape(collectorNumber))
+	resp, err := c.get(ctx, printingURL)
+	var dnsError *net.DNSError
+	if errors.As(err, &dnsError) {
+		log.Println("mtgsdk - failed to connect to host, looking up the printing in the card store")
+		return c.getCardByPrintingOffline(setCode, collectorNumber)
+	}
+	if err != nil {
+		return Card{}, err
+	}
+	defer resp.Body.Close()
+	var card Card
+	err = json.NewDecoder(resp.Body).Decode(&card)
+	if err != nil {
+		return Card{}, err
+	}
+	if card.ID == "" {
+		return Card{}, fmt.Errorf("mtgsdk - fetched card %s %s, but it doesn't have an id", setCode, collectorNumber)
+	}
+	err = c.saveCard(card)
+	if err != nil {
+		return Card{}, err
+	}
+	return card, nil
+}
+
+// Returns the card with the set code and the collector number from the card store
+func (c *Client) getCardByPrintingOffline(setCode string, collectorNumber string) (Card, error) {
+	cards, err := c.store.FindBySet(setCode)
+	if err != nil {
+		return Card{}, err
+	}
+	for _, card := range cards {
+		if strings.EqualFold(card.CollectorNumber, collectorNumber) {
+			return card, nil
+		}
+	}
+	return Card{}, fmt.Errorf("mtgsdk - card %s %s is not in the card store: %w", setCode, collectorNumber, ErrNotFound)
+}
+
 // Returns the map of basic lands
-func GetBasicLands(offline bool) (map[string]Card, error) {
+func (c *Client) GetBasicLands(offline bool) (map[string]Card, error) {
+	return c.GetBasicLandsContext(context.Background(), offline)
+}
+
+// Same as GetBasicLands, but uses the context for the network requests
+func (c *Client) GetBasicLandsContext(ctx context.Context, offline bool) (map[string]Card, error) {
 	blnames := []string{"Plains", "Island", "Swamp", "Mountain", "Forest"}
 	result := map[string]Card{}
 	for _, blname := range blnames {
-		cards, err := GetCards(map[string]string{CardNameKey: blname}, offline)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		card, err := c.resolveName(ctx, blname, NameExact, offline)
 		if err != nil {
 			return nil, err
 		}
-		result[blname] = cards[0]
+		result[blname] = card
 	}
 	return result, nil
 }
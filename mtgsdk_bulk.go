@@ -0,0 +1,209 @@
+package mtgsdk
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+const (
+	bulkDataPath          = "/bulk-data/" // the path for the bulk data objects
+	bulkProgressFrequency = 1000          // the amount of cards between two progress reports
+)
+
+// A type of scryfall bulk data file
+type BulkDataType string
+
+const (
+	BulkOracleCards   BulkDataType = "oracle_cards"   // One card per oracle id
+	BulkUniqueArtwork BulkDataType = "unique_artwork" // One card per unique artwork
+	BulkDefaultCards  BulkDataType = "default_cards"  // Every card in english or the printed language
+	BulkAllCards      BulkDataType = "all_cards"      // Every card in every language
+)
+
+// The progress of a bulk data import
+type ImportProgress struct {
+	Cards      int   // The amount of imported cards
+	BytesRead  int64 // The amount of read bytes
+	TotalBytes int64 // The size of the file (0 if unknown)
+	Done       bool  // True if the import is finished
+}
+
+// A function that receives the progress of a bulk data import
+type ProgressFunc func(ImportProgress)
+
+// A scryfall bulk data object
+type bulkDataObject struct {
+	Type        string `json:"type"`         // The type of the bulk data
+	DownloadURI string `json:"download_uri"` // The url of the file
+	Size        int64  `json:"size"`         // The size of the file in bytes
+	UpdatedAt   string `json:"updated_at"`   // The time the file was updated
+}
+
+// Returns an error if the card store of the client can't hold the bulk data
+//
+// The json store keeps every card in memory and rewrites the whole file on every batch, which doesn't scale to bulk files
+func (c *Client) checkBulkStore() error {
+	if _, isJSON := c.store.(*JSONCardStore); isJSON {
+		return fmt.Errorf("mtgsdk - bulk data can't be imported into the json card store, use the default bbolt card store")
+	}
+	return nil
+}
+
+// A reader that counts the read bytes
+type countingReader struct {
+	r     io.Reader
+	count int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// Imports the cards from a downloaded scryfall bulk data file (optionally gzipped)
+//
+// Returns the amount of imported cards. Needs a card store that doesn't keep the cards in memory (the default BoltCardStore, not the JSONCardStore)
+func (c *Client) ImportBulkFile(path string, progress ProgressFunc) (int, error) {
+	return c.ImportBulkFileContext(context.Background(), path, progress)
+}
+
+// Same as ImportBulkFile, but uses the context for the network requests
+func (c *Client) ImportBulkFileContext(ctx context.Context, path string, progress ProgressFunc) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return c.ImportBulkReaderContext(ctx, file, info.Size(), progress)
+}
+
+// Downloads the scryfall bulk data file of the specified type and imports its cards
+//
+// Returns the amount of imported cards. Needs a card store that doesn't keep the cards in memory (the default BoltCardStore, not the JSONCardStore)
+func (c *Client) ImportBulkData(kind BulkDataType, progress ProgressFunc) (int, error) {
+	return c.ImportBulkDataContext(context.Background(), kind, progress)
+}
+
+// Same as ImportBulkData, but uses the context for the network requests
+func (c *Client) ImportBulkDataContext(ctx context.Context, kind BulkDataType, progress ProgressFunc) (int, error) {
+	// check the store before downloading the file
+	err := c.checkBulkStore()
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.get(ctx, c.apiURL+bulkDataPath+string(kind))
+	if err != nil {
+		return 0, err
+	}
+	var object bulkDataObject
+	err = json.NewDecoder(resp.Body).Decode(&object)
+	resp.Body.Close()
+	if err != nil {
+		return 0, err
+	}
+	if object.DownloadURI == "" {
+		return 0, fmt.Errorf("mtgsdk - bulk data %s doesn't have a download uri", kind)
+	}
+	log.Printf("mtgsdk - downloading bulk data %s (updated at %s) from %s", kind, object.UpdatedAt, object.DownloadURI)
+	resp, err = c.get(ctx, object.DownloadURI)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	size := object.Size
+	if resp.ContentLength > 0 {
+		size = resp.ContentLength
+	}
+	return c.ImportBulkReaderContext(ctx, resp.Body, size, progress)
+}
+
+// Imports the cards from a scryfall bulk data stream (optionally gzipped)
+//
+// The stream is decoded one card at a time, so the whole array is never loaded in memory.
+// Needs a card store that doesn't keep the cards in memory (the default BoltCardStore, not the JSONCardStore)
+func (c *Client) ImportBulkReader(r io.Reader, size int64, progress ProgressFunc) (int, error) {
+	return c.ImportBulkReaderContext(context.Background(), r, size, progress)
+}
+
+// Same as ImportBulkReader, but uses the context for the network requests
+func (c *Client) ImportBulkReaderContext(ctx context.Context, r io.Reader, size int64, progress ProgressFunc) (int, error) {
+	err := c.checkBulkStore()
+	if err != nil {
+		return 0, err
+	}
+	counter := &countingReader{r: r}
+	buffered := bufio.NewReader(counter)
+	var reader io.Reader = buffered
+	// check for the gzip magic bytes
+	magic, err := buffered.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return 0, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	report := func(count int, done bool) {
+		if progress != nil {
+			progress(ImportProgress{Cards: count, BytesRead: counter.count, TotalBytes: size, Done: done})
+		}
+	}
+	decoder := json.NewDecoder(reader)
+	token, err := decoder.Token()
+	if err != nil {
+		return 0, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return 0, fmt.Errorf("mtgsdk - bulk data is not a json array")
+	}
+	count := 0
+	batch := make([]Card, 0, storeBatchSize)
+	for decoder.More() {
+		var card Card
+		err = decoder.Decode(&card)
+		if err != nil {
+			return count, err
+		}
+		if card.ID == "" {
+			continue
+		}
+		batch = append(batch, card)
+		count++
+		if len(batch) == storeBatchSize {
+			if ctx.Err() != nil {
+				return count, ctx.Err()
+			}
+			err = c.store.PutBatch(batch)
+			if err != nil {
+				return count, err
+			}
+			batch = batch[:0]
+		}
+		if count%bulkProgressFrequency == 0 {
+			report(count, false)
+		}
+	}
+	_, err = decoder.Token()
+	if err != nil {
+		return count, err
+	}
+	err = c.store.PutBatch(batch)
+	if err != nil {
+		return count, err
+	}
+	report(count, true)
+	log.Printf("mtgsdk - imported %d cards from bulk data", count)
+	return count, nil
+}
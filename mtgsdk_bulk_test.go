@@ -0,0 +1,124 @@
+package mtgsdk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+)
+
+const (
+	bulkTestCardCount = bulkProgressFrequency + 200 // the amount of cards in the bulk test fixture (enough for a progress report before the end)
+)
+
+// Returns a bulk data fixture with bulkTestCardCount cards, the first one replaces an existing card
+func bulkFixture(t *testing.T) []byte {
+	cards := make([]Card, bulkTestCardCount)
+	for i := range cards {
+		cards[i] = Card{ID: fmt.Sprintf("bulk-%d", i), Name: fmt.Sprintf("Bulk Card %d", i), Set: "blk"}
+	}
+	cards[0].Name = "Updated Card"
+	data, err := json.Marshal(cards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// Returns the gzipped data
+func gzipData(t *testing.T, data []byte) []byte {
+	var buffer bytes.Buffer
+	w := gzip.NewWriter(&buffer)
+	_, err := w.Write(data)
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+// Checks that the cards of the fixture are in the store of the client and that the progress was reported
+func checkBulkImport(t *testing.T, client *Client, count int, reports []ImportProgress) {
+	t.Helper()
+	if count != bulkTestCardCount {
+		t.Fatalf("expected %d imported cards, got %d", bulkTestCardCount, count)
+	}
+	stored, err := client.Store().Len()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored != bulkTestCardCount {
+		t.Fatalf("expected %d cards in the store, got %d", bulkTestCardCount, stored)
+	}
+	card, found, err := client.Store().Get("bulk-0")
+	if err != nil || !found || card.Name != "Updated Card" {
+		t.Fatalf("expected the existing card to be replaced, got %v %v %v", card, found, err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 progress reports, got %v", reports)
+	}
+	if reports[0].Cards != bulkProgressFrequency || reports[0].Done {
+		t.Fatalf("expected a progress report after %d cards, got %+v", bulkProgressFrequency, reports[0])
+	}
+	last := reports[1]
+	if !last.Done || last.Cards != bulkTestCardCount || last.BytesRead == 0 || last.BytesRead != last.TotalBytes {
+		t.Fatalf("expected the final progress report of the whole file, got %+v", last)
+	}
+}
+
+func TestImportBulkFile(t *testing.T) {
+	client := newTestClient(t, nil)
+	err := client.Store().Put(Card{ID: "bulk-0", Name: "Old Card"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := path.Join(t.TempDir(), "cards.json")
+	err = os.WriteFile(file, bulkFixture(t), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports := []ImportProgress{}
+	count, err := client.ImportBulkFile(file, func(p ImportProgress) { reports = append(reports, p) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBulkImport(t, client, count, reports)
+}
+
+func TestImportBulkDataGzipped(t *testing.T) {
+	data := gzipData(t, bulkFixture(t))
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case bulkDataPath + string(BulkDefaultCards):
+			json.NewEncoder(w).Encode(bulkDataObject{Type: string(BulkDefaultCards), DownloadURI: "http://" + r.Host + "/cards.json.gz", Size: int64(len(data))})
+		case "/cards.json.gz":
+			w.Write(data)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	err := client.Store().Put(Card{ID: "bulk-0", Name: "Old Card"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports := []ImportProgress{}
+	count, err := client.ImportBulkData(BulkDefaultCards, func(p ImportProgress) { reports = append(reports, p) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBulkImport(t, client, count, reports)
+}
+
+func TestImportBulkReaderRejectsJSONStore(t *testing.T) {
+	client := newTestClient(t, nil, WithJSONCardStore())
+	_, err := client.ImportBulkReader(bytes.NewReader(bulkFixture(t)), 0, nil)
+	if err == nil {
+		t.Fatal("expected the json card store to be rejected")
+	}
+}
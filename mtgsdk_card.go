@@ -1,10 +1,10 @@
 package mtgsdk
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"os"
 	"path"
 	"strings"
@@ -15,36 +15,41 @@ import (
 
 // A card struct
 type Card struct {
-	ID        string   `json:"id"`        // The id of the card (used as key in maps)
-	OracleID  string   `json:"oracle_id"` // The oracle id for the card
-	Name      string   `json:"name"`      // The name of the card
-	ImageUris struct { // URLs for the card images
-		Small  string `json:"small"`
-		Normal string `json:"normal"`
-		Large  string `json:"large"`
-	} `json:"image_uris"`
-	ManaCost        string   `json:"mana_cost"`      // The raw manacost of the card
-	Cmc             float64  `json:"cmc"`            // The converted manacost of the card
-	TypeLine        string   `json:"type_line"`      // The card type line
-	OracleText      string   `json:"oracle_text"`    // The oracled text of the card
-	Colors          []string `json:"colors"`         // Card colors
-	ColorIdentity   []string `json:"color_identity"` // The color identity of the card
-	Keywords        []string `json:"keywords"`       // The keywords of the card
-	SetID           string   `json:"set_id"`         // The ID of the set of the card
-	Set             string   `json:"set"`            // The set codename of the card
-	SetName         string   `json:"set_name"`       // The actual set name
-	SetURI          string   `json:"set_uri"`        // The URI to the set
-	SetSearchURI    string   `json:"set_search_uri"` // The URI to serach for the set
-	ScryfallSetURI  string   `json:"scryfall_set_uri"`
-	RulingsURI      string   `json:"rulings_uri"`
-	PrintsSearchURI string   `json:"prints_search_uri"`
-	Rarity          string   `json:"rarity"`          // The rarity of the card
-	CardBackID      string   `json:"card_back_id"`    // The ID of the back of the card (if is double-sided)
-	ArtistIds       []string `json:"artist_ids"`      // ID of the artist
-	IllustrationID  string   `json:"illustration_id"` // ID of the illustration
-	BorderColor     string   `json:"border_color"`    // The color of the border
-	Power           string   `json:"power"`           // The power of the card
-	Toughness       string   `json:"toughness"`       // The toughness of the card
+	ID              string       `json:"id"`               // The id of the card (used as key in maps)
+	OracleID        string       `json:"oracle_id"`        // The oracle id for the card
+	Name            string       `json:"name"`             // The name of the card
+	ImageUris       ImageURIs    `json:"image_uris"`       // URLs for the card images (empty for double-faced cards, see CardFaces)
+	Layout          string       `json:"layout"`           // The layout of the card (normal, transform, split, ...)
+	CardFaces       []CardFace   `json:"card_faces"`       // The faces of the multi-faced cards
+	ManaCost        string       `json:"mana_cost"`        // The raw manacost of the card
+	Cmc             float64      `json:"cmc"`              // The converted manacost of the card
+	TypeLine        string       `json:"type_line"`        // The card type line
+	OracleText      string       `json:"oracle_text"`      // The oracled text of the card
+	Colors          []string     `json:"colors"`           // Card colors
+	ColorIdentity   []string     `json:"color_identity"`   // The color identity of the card
+	Keywords        []string     `json:"keywords"`         // The keywords of the card
+	SetID           string       `json:"set_id"`           // The ID of the set of the card
+	Set             string       `json:"set"`              // The set codename of the card
+	CollectorNumber string       `json:"collector_number"` // The collector number of the card in the set
+	MtgoID          int          `json:"mtgo_id"`          // The mtgo catalog id of the card (0 if not on mtgo)
+	Finishes        []Finish     `json:"finishes"`         // The finishes the card is printed in
+	SetName         string       `json:"set_name"`         // The actual set name
+	SetURI          string       `json:"set_uri"`          // The URI to the set
+	SetSearchURI    string       `json:"set_search_uri"`   // The URI to serach for the set
+	ScryfallSetURI  string       `json:"scryfall_set_uri"`
+	RulingsURI      string       `json:"rulings_uri"`
+	PrintsSearchURI string       `json:"prints_search_uri"`
+	Rarity          string       `json:"rarity"`          // The rarity of the card
+	CardBackID      string       `json:"card_back_id"`    // The ID of the back of the card (if is double-sided)
+	ArtistIds       []string     `json:"artist_ids"`      // ID of the artist
+	IllustrationID  string       `json:"illustration_id"` // ID of the illustration
+	BorderColor     string       `json:"border_color"`    // The color of the border
+	Power           string       `json:"power"`           // The power of the card
+	Toughness       string       `json:"toughness"`       // The toughness of the card
+	Loyalty         string       `json:"loyalty"`         // The loyalty of the card
+	Legalities      Legalities   `json:"legalities"`      // The legalities of the card in the formats
+	Prices          Prices       `json:"prices"`          // The prices of the card
+	PurchaseURIs    PurchaseURIs `json:"purchase_uris"`   // The links to buy the card
 }
 
 // Prints out the card to the console
@@ -124,7 +129,23 @@ func (c Card) prettySplitText() ([]string, error) {
 }
 
 // Prints the card as a card
+//
+// Multi-faced cards are printed face by face
 func (c Card) CardPrint() error {
+	if !c.IsMultiFaced() {
+		return c.printFace()
+	}
+	for _, face := range c.CardFaces {
+		err := c.faceCard(face).printFace()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prints a single face of the card
+func (c Card) printFace() error {
 	var cardColor string
 	if len(c.Colors) == 0 {
 		// nameColor = nil
@@ -185,47 +206,66 @@ func (c Card) CardPrint() error {
 }
 
 // Downloads the card image to the specified path
-func (c Card) DownloadImage(outPath string, quality ImageQuality) error {
+func (c *Client) DownloadImage(card Card, outPath string, quality ImageQuality) error {
+	return c.DownloadImageContext(context.Background(), card, outPath, quality)
+}
+
+// Same as DownloadImage, but uses the context for the network requests
+func (c *Client) DownloadImageContext(ctx context.Context, card Card, outPath string, quality ImageQuality) error {
+	sides := card.SideImageURIs()
+	if len(sides) == 0 {
+		log.Printf("mtgsdk - can't download images for card %v", card.ID)
+		return nil
+	}
+	for i, side := range sides {
+		err := c.downloadSideImage(ctx, card, i, side, outPath, quality)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Downloads the image of a side of the card to the specified path
+//
+// The front side is saved as <id>_<quality>.jpg, the other sides as <id>_<quality>_<side>.jpg
+func (c *Client) downloadSideImage(ctx context.Context, card Card, side int, uris ImageURIs, outPath string, quality ImageQuality) error {
 	// get request
-	imageURL := ""
+	imageURL := uris.get(quality)
 	q := ""
 	switch quality {
 	case ImageQualitySmall:
-		imageURL = c.ImageUris.Small
 		q = "small"
 	case ImageQualityNormal:
-		imageURL = c.ImageUris.Normal
 		q = "normal"
 	case ImageQualityLarge:
-		imageURL = c.ImageUris.Large
 		q = "large"
 	}
-	fileName := fmt.Sprintf("%v_%v.%v", c.ID, q, imageFileFormat)
+	fileName := fmt.Sprintf("%v_%v.%v", card.ID, q, imageFileFormat)
+	if side > 0 {
+		fileName = fmt.Sprintf("%v_%v_%d.%v", card.ID, q, side, imageFileFormat)
+	}
 	appdataPath := path.Join(imagesFolder, fileName)
 	resultPath := path.Join(outPath, fileName)
 	// check whether image already exists
-	exists, err := adm.FileExists(appdataPath)
+	exists, err := c.adm.FileExists(appdataPath)
 	if err != nil {
 		return err
 	}
 	if !exists {
 		// file doesn't exist locally, downloading it
-		log.Printf("mtgsdk - image of quality %v for card %v doesn't exist locally, downloading it", q, c.ID)
+		log.Printf("mtgsdk - image of quality %v for card %v doesn't exist locally, downloading it", q, card.ID)
 		if imageURL == "" {
-			log.Printf("mtgsdk - can't download images for card %v", c.ID)
+			log.Printf("mtgsdk - can't download images for card %v", card.ID)
 			return nil
 		}
-		response, err := http.Get(imageURL)
+		response, err := c.get(ctx, imageURL)
 		if err != nil {
 			return err
 		}
 		defer response.Body.Close()
-		// check status code
-		if response.StatusCode != 200 {
-			return fmt.Errorf("received a non 200 response when downloading from %v", imageURL)
-		}
 		// create output file
-		file, err := os.Create(adm.ConcatPath(appdataPath))
+		file, err := os.Create(c.adm.ConcatPath(appdataPath))
 		if err != nil {
 			return err
 		}
@@ -236,48 +276,46 @@ func (c Card) DownloadImage(outPath string, quality ImageQuality) error {
 			return err
 		}
 	}
-	contents, err := adm.ReadFile(appdataPath)
+	contents, err := c.adm.ReadFile(appdataPath)
 	if err != nil {
 		return err
 	}
 	os.WriteFile(resultPath, contents, 0755)
-	log.Printf("mtgsdk - saved image for %v", c.ID)
+	log.Printf("mtgsdk - saved image for %v", card.ID)
 	return nil
 }
 
 // Returns true if the cards matches all the specified params
 func (c Card) Matches(params map[string]string) bool {
-	for key, value := range params {
-		switch key {
-		case CardNameKey:
-			if !strings.Contains(c.Name, value) {
-				return false
-			}
-		case SetNameKey:
-			if !strings.Contains(c.SetName, value) {
-				return false
-			}
-		}
-	}
-	return true
+	return c.MatchesQuery(paramsToQuery(params))
+}
+
+// Returns true if the card matches the query
+func (c Card) MatchesQuery(q QueryNode) bool {
+	return q.Match(c)
 }
 
 // Returns the map of card ids and their synergies (only applies to legendary creatures)
-func (c Card) GetReccomendations(synergy int, offline bool) (map[*Card]int, error) {
-	if c.IsLegendary() && c.IsCreature() {
-		recc, err := reccomendCards(c.ID, offline)
+func (c *Client) GetReccomendations(card Card, synergy int, offline bool) (map[*Card]int, error) {
+	return c.GetReccomendationsContext(context.Background(), card, synergy, offline)
+}
+
+// Same as GetReccomendations, but uses the context for the network requests
+func (c *Client) GetReccomendationsContext(ctx context.Context, card Card, synergy int, offline bool) (map[*Card]int, error) {
+	if card.IsLegendary() && card.IsCreature() {
+		recc, err := c.reccomendCards(ctx, card.ID, offline)
 		if err != nil {
 			return nil, err
 		}
 		result := make(map[*Card]int)
-		for card, syn := range recc {
+		for rcard, syn := range recc {
 			if syn >= synergy {
-				result[card] = syn
+				result[rcard] = syn
 			}
 		}
 		return result, nil
 	} else {
-		return nil, fmt.Errorf("mtgsdk - can't get reccomendations for non-legendary creature (%s)", c.Name)
+		return nil, fmt.Errorf("mtgsdk - can't get reccomendations for non-legendary creature (%s)", card.Name)
 	}
 }
 
@@ -292,33 +330,58 @@ func (c Card) IsLegendary() bool {
 }
 
 // Returns true if the card is a land
+//
+// Only the front face is checked, so modal double-faced spells with a land back are not lands
 func (c Card) IsLand() bool {
-	return strings.Contains(c.TypeLine, "Land")
+	return strings.Contains(c.FrontFace().TypeLine, "Land")
+}
+
+// Returns true if the card is a permanent (only the front face is checked, so modal double-faced spells with a land back are not)
+func (c Card) IsPermanent() bool {
+	for _, t := range []string{"Artifact", "Battle", "Creature", "Enchantment", "Land", "Planeswalker"} {
+		if strings.Contains(c.FrontFace().TypeLine, t) {
+			return true
+		}
+	}
+	return false
 }
 
-// Returns true if the card is a basic land
+// Returns true if the card is a basic land (including snow basics like "Basic Snow Land — Forest")
 func (c Card) IsBasicLand() bool {
-	return strings.Contains(c.TypeLine, "Basic Land")
+	// only the types before the dash are checked, the subtypes after it can't be "Basic"
+	types := strings.Fields(strings.Split(c.FrontFace().TypeLine, "—")[0])
+	basic, land := false, false
+	for _, t := range types {
+		switch t {
+		case "Basic":
+			basic = true
+		case "Land":
+			land = true
+		}
+	}
+	return basic && land
 }
 
 // Returns true if the card can generate mana
 func (c Card) IsRamp() bool {
-	return !c.IsLand() && strings.Contains(c.OracleText, "Add ") || strings.Contains(c.OracleText, "your library for a basic land card, put that card onto the battlefield tapped")
+	text := c.FullOracleText()
+	return !c.IsLand() && strings.Contains(text, "Add ") || strings.Contains(text, "your library for a basic land card, put that card onto the battlefield tapped")
 }
 
 // Returns true if the card is a board wipe
 func (c Card) IsBoardWipe() bool {
-	return strings.Contains(c.OracleText, "Destroy all ") || strings.Contains(c.OracleText, " damage to each creature") || strings.Contains(c.OracleText, "All creatures get -")
+	text := c.FullOracleText()
+	return strings.Contains(text, "Destroy all ") || strings.Contains(text, " damage to each creature") || strings.Contains(text, "All creatures get -")
 }
 
 // Returns true if the card forces the player to draw cards
 func (c Card) IsCardDraw() bool {
-	return strings.Contains(strings.ToLower(c.OracleText), "draw ")
+	return strings.Contains(strings.ToLower(c.FullOracleText()), "draw ")
 }
 
 // Returns true if the card is a removal card
 func (c Card) IsRemoval() bool {
-	return strings.Contains(strings.ToLower(c.OracleText), "destroy target")
+	return strings.Contains(strings.ToLower(c.FullOracleText()), "destroy target")
 }
 
 // Returns true if the colors match the color identity of the card
@@ -343,7 +406,7 @@ func (c Card) CountColorPips() map[string]int {
 	cpips := strings.Split("WUBRG", "")
 	result := map[string]int{}
 	for _, pip := range cpips {
-		amount := strings.Count(c.ManaCost, pip)
+		amount := strings.Count(c.FullManaCost(), pip)
 		_, has := result[pip]
 		if !has {
 			result[pip] = 0
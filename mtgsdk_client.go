@@ -0,0 +1,376 @@
+package mtgsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GrandOichii/appdata"
+	"github.com/go-rod/rod"
+)
+
+const (
+	appDataFolder       = "mtgsdk-data"              // the name of the appdata folder
+	defaultAPIURL       = "https://api.scryfall.com" // the url of the api for fetching card data
+	defaultEDHRECURL    = "https://edhrec.com"       // the url of edhrec.com
+	cardIDSearchPath    = "/cards/"                  // the path for searching for cards by id
+	cardQuerySearchPath = "/cards/search?q="         // the path for searching for cards by query
+	cardPrintingPath    = "/cards/%s/%s"             // the path for searching for cards by set code and collector number
+	commanderSearchPath = "/commanders/%s"           // the path for commander pages on edhrec
+	staplesPath         = "/top"                     // the path for the edhrec staples page
+	setsPath            = "/sets"                    // the path for the list of all sets
+)
+
+// Storage of the client data files
+//
+// Implemented by appDataManager and dirDataManager
+type dataManager interface {
+	ReadFile(file string) ([]byte, error)
+	WriteToFile(file string, data []byte) error
+	FileExists(file string) (bool, error)
+	CreateFolder(folderPath string) error
+	ConcatPath(file string) string
+	ListFiles(folderPath string) ([]string, error)
+	RemoveFile(file string) error
+}
+
+// A data manager that stores the files in the appdata folder
+//
+// Adds listing and removing files to appdata.AppDataManager
+type appDataManager struct {
+	appdata.AppDataManager
+}
+
+func (m appDataManager) ListFiles(folderPath string) ([]string, error) {
+	return listFiles(m.ConcatPath(folderPath))
+}
+
+func (m appDataManager) RemoveFile(file string) error {
+	return os.Remove(m.ConcatPath(file))
+}
+
+// Returns the names of the files in the directory (without the subdirectories)
+func listFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			result = append(result, entry.Name())
+		}
+	}
+	return result, nil
+}
+
+// A data manager that stores the files in a plain directory
+type dirDataManager struct {
+	dir string
+}
+
+// Creates the directory and the data manager for it
+func createDirDataManager(dir string) (dirDataManager, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return dirDataManager{}, err
+	}
+	return dirDataManager{dir: dir}, nil
+}
+
+func (m dirDataManager) ConcatPath(file string) string {
+	return path.Join(m.dir, file)
+}
+
+func (m dirDataManager) ReadFile(file string) ([]byte, error) {
+	return os.ReadFile(m.ConcatPath(file))
+}
+
+func (m dirDataManager) WriteToFile(file string, data []byte) error {
+	return os.WriteFile(m.ConcatPath(file), data, 0755)
+}
+
+func (m dirDataManager) FileExists(file string) (bool, error) {
+	_, err := os.Stat(m.ConcatPath(file))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m dirDataManager) CreateFolder(folderPath string) error {
+	return os.Mkdir(m.ConcatPath(folderPath), 0755)
+}
+
+func (m dirDataManager) ListFiles(folderPath string) ([]string, error) {
+	return listFiles(m.ConcatPath(folderPath))
+}
+
+func (m dirDataManager) RemoveFile(file string) error {
+	return os.Remove(m.ConcatPath(file))
+}
+
+// A client for the scryfall api and edhrec.com
+//
+// Holds the local card cache, the edhrec data and the http client
+type Client struct {
+	adm          dataManager               // the data manager
+	httpClient   *http.Client              // the client for all http requests
+	apiURL       string                    // the url of the scryfall api
+	edhrecURL    string                    // the url of edhrec.com
+	store        CardStore                 // the local card store
+	useJSONStore bool                      // true if the default card store is the json file instead of the bbolt database
+	edhrecData   map[string]map[string]int // the map of all commanders and their reccomendations (card.id -- synergy)
+	edhrecMu     sync.RWMutex              // guards edhrecData
+	edhrecSaveMu sync.Mutex                // serializes the writes of the edhrec data file
+	browser      *rod.Browser              // the browser that accesses the edhrec website
+	browserMu    sync.Mutex                // guards browser
+	transport    *RateLimitedTransport     // the transport that limits the requests (nil if disabled)
+	decksMu      sync.Mutex                // guards the saved decks
+	setCodes     map[string]string         // the map of the set codes (lower case set.name -- set.code), nil until fetched
+	setCodesMu   sync.Mutex                // guards setCodes
+}
+
+// An option for NewClient
+type ClientOption func(*Client) error
+
+// Stores the client data in the specified directory instead of the appdata folder
+func WithDataDir(dir string) ClientOption {
+	return func(c *Client) error {
+		m, err := createDirDataManager(dir)
+		if err != nil {
+			return err
+		}
+		c.adm = m
+		return nil
+	}
+}
+
+// Stores the client data using the specified appdata manager
+func WithAppDataManager(adm appdata.AppDataManager) ClientOption {
+	return func(c *Client) error {
+		c.adm = appDataManager{adm}
+		return nil
+	}
+}
+
+// Sets the http client used for all requests
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) error {
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// Stores the cards in the specified card store
+//
+// The store is closed by Client.Close
+func WithCardStore(store CardStore) ClientOption {
+	return func(c *Client) error {
+		c.store = store
+		return nil
+	}
+}
+
+// Stores the cards in a bbolt database in the data directory
+//
+// The bbolt database is the default card store, the option only overrides an earlier WithJSONCardStore
+func WithBoltCardStore() ClientOption {
+	return func(c *Client) error {
+		c.useJSONStore = false
+		return nil
+	}
+}
+
+// Stores the cards in a json file in the data directory instead of the bbolt database
+//
+// The json store keeps every card in memory, so bulk data can't be imported into it
+func WithJSONCardStore() ClientOption {
+	return func(c *Client) error {
+		c.useJSONStore = true
+		return nil
+	}
+}
+
+// Sets the minimum delay between two requests
+func WithRateLimit(interval time.Duration) ClientOption {
+	return func(c *Client) error {
+		if c.transport == nil {
+			c.transport = NewRateLimitedTransport(nil)
+		}
+		c.transport.Interval = interval
+		return nil
+	}
+}
+
+// Sets the retry policy for throttled (429) and failed (5xx) requests
+func WithRetries(maxRetries int, baseBackoff time.Duration, maxBackoff time.Duration) ClientOption {
+	return func(c *Client) error {
+		if c.transport == nil {
+			c.transport = NewRateLimitedTransport(nil)
+		}
+		c.transport.MaxRetries = maxRetries
+		c.transport.BaseBackoff = baseBackoff
+		c.transport.MaxBackoff = maxBackoff
+		return nil
+	}
+}
+
+// Disables the rate limiting and the retries of requests
+func WithoutRateLimit() ClientOption {
+	return func(c *Client) error {
+		c.transport = nil
+		return nil
+	}
+}
+
+// Sets the base url of the scryfall api
+func WithAPIURL(url string) ClientOption {
+	return func(c *Client) error {
+		c.apiURL = strings.TrimRight(url, "/")
+		return nil
+	}
+}
+
+// Sets the base url of edhrec.com
+func WithEDHRECURL(url string) ClientOption {
+	return func(c *Client) error {
+		c.edhrecURL = strings.TrimRight(url, "/")
+		return nil
+	}
+}
+
+// Creates a new client
+//
+// If no data directory is specified, the data is stored in the mtgsdk-data appdata folder. The cards are stored
+// in a bbolt database (see WithJSONCardStore for the json file)
+func NewClient(opts ...ClientOption) (*Client, error) {
+	result := &Client{
+		httpClient: http.DefaultClient,
+		apiURL:     defaultAPIURL,
+		edhrecURL:  defaultEDHRECURL,
+		transport:  NewRateLimitedTransport(nil),
+	}
+	for _, opt := range opts {
+		err := opt(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if result.transport != nil {
+		// wrap the transport of a copy, so that the passed http client is not modified
+		httpClient := *result.httpClient
+		result.transport.Base = httpClient.Transport
+		httpClient.Transport = result.transport
+		result.httpClient = &httpClient
+	}
+	if result.adm == nil {
+		adm, err := appdata.CreateAppDataManager(appDataFolder)
+		if err != nil {
+			return nil, err
+		}
+		result.adm = appDataManager{adm}
+	}
+	// create the edhrec data file
+	err := result.createEDHRECFiles()
+	if err != nil {
+		return nil, err
+	}
+	// create imagesFolder folder
+	err = result.createCardImagesFolder()
+	if err != nil {
+		return nil, err
+	}
+	// create the decks folder
+	err = result.createDecksFolder()
+	if err != nil {
+		return nil, err
+	}
+	// open the card store
+	err = result.openCardStore()
+	if err != nil {
+		return nil, err
+	}
+	// load edhrec data
+	err = result.loadEDHRECData()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Closes the card store and the browser
+//
+// The store is closed even if the browser fails to close. If both fail, the errors are returned as a MultiError
+func (c *Client) Close() error {
+	c.browserMu.Lock()
+	defer c.browserMu.Unlock()
+	var browserErr error
+	if c.browser != nil {
+		browserErr = c.browser.Close()
+		c.browser = nil
+	}
+	return joinErrors(browserErr, c.store.Close())
+}
+
+// Returns the card store of the client
+func (c *Client) Store() CardStore {
+	return c.store
+}
+
+// Returns the path to the client data directory
+func (c *Client) DataDir() string {
+	return c.adm.ConcatPath("")
+}
+
+// Sends a GET request to the url
+//
+// Returns a ScryfallError if the response status is not 2xx
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	err = checkResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Sends a POST request with the json body to the url
+//
+// Returns a ScryfallError if the response status is not 2xx
+func (c *Client) postJSON(ctx context.Context, url string, body interface{}) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	err = checkResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,225 @@
+package mtgsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"strings"
+)
+
+const (
+	cardCollectionPath  = "/cards/collection" // the path for looking up cards by identifiers
+	collectionBatchSize = 75                  // the maximum amount of identifiers in a single collection request
+)
+
+// An identifier of a card for batch lookups
+//
+// Only one of ID, OracleID, Name (optionally with Set) or Set with CollectorNumber has to be set
+type CardIdentifier struct {
+	ID              string `json:"id,omitempty"`               // The scryfall id of the card
+	OracleID        string `json:"oracle_id,omitempty"`        // The oracle id of the card
+	Name            string `json:"name,omitempty"`             // The name of the card
+	Set             string `json:"set,omitempty"`              // The set code of the card
+	CollectorNumber string `json:"collector_number,omitempty"` // The collector number of the card in the set
+}
+
+// Returns the identifier of the card with the id
+func IdentifierByID(id string) CardIdentifier {
+	return CardIdentifier{ID: id}
+}
+
+// Returns the identifier of the card with the oracle id
+func IdentifierByOracleID(oracleID string) CardIdentifier {
+	return CardIdentifier{OracleID: oracleID}
+}
+
+// Returns the identifier of the card with the name (setCode can be empty)
+func IdentifierByName(name string, setCode string) CardIdentifier {
+	return CardIdentifier{Name: name, Set: setCode}
+}
+
+// Returns the identifier of the card with the set code and the collector number
+func IdentifierByPrinting(setCode string, collectorNumber string) CardIdentifier {
+	return CardIdentifier{Set: setCode, CollectorNumber: collectorNumber}
+}
+
+// Returns true if the identifier identifies the card
+func (i CardIdentifier) matches(card Card) bool {
+	switch {
+	case i.ID != "":
+		return card.ID == i.ID
+	case i.OracleID != "":
+		return card.OracleID == i.OracleID
+	case i.Name != "":
+		if i.Set != "" && !strings.EqualFold(card.Set, i.Set) {
+			return false
+		}
+		for _, name := range cardNames(card) {
+			if normalizeName(name) == normalizeName(i.Name) {
+				return true
+			}
+		}
+		return false
+	case i.Set != "" && i.CollectorNumber != "":
+		return strings.EqualFold(card.Set, i.Set) && strings.EqualFold(card.CollectorNumber, i.CollectorNumber)
+	}
+	return false
+}
+
+// The body of a collection request
+type collectionRequest struct {
+	Identifiers []CardIdentifier `json:"identifiers"`
+}
+
+// The response of a collection request
+type collectionResponse struct {
+	Data     []Card           `json:"data"`      // The found cards (in the order of the identifiers)
+	NotFound []CardIdentifier `json:"not_found"` // The identifiers that didn't match any card
+}
+
+// Returns the cards for the identifiers
+//
+// The card store is checked first, the rest of the cards are fetched in batches of 75. The found cards are returned
+// in the order of the identifiers, the identifiers without a card are returned separately.
+// If offline is true (or can't connect to scryfall), only the card store is checked
+func (c *Client) GetCardsByIdentifiers(identifiers []CardIdentifier, offline bool) ([]Card, []CardIdentifier, error) {
+	return c.GetCardsByIdentifiersContext(context.Background(), identifiers, offline)
+}
+
+// Same as GetCardsByIdentifiers, but uses the context for the network requests
+func (c *Client) GetCardsByIdentifiersContext(ctx context.Context, identifiers []CardIdentifier, offline bool) ([]Card, []CardIdentifier, error) {
+	found, err := c.getCardsByIdentifiers(ctx, identifiers, offline)
+	if err != nil {
+		return nil, nil, err
+	}
+	cards := []Card{}
+	notFound := []CardIdentifier{}
+	for i, card := range found {
+		if card == nil {
+			notFound = append(notFound, identifiers[i])
+			continue
+		}
+		cards = append(cards, *card)
+	}
+	return cards, notFound, nil
+}
+
+// Returns the cards for the identifiers (nil if the card wasn't found)
+func (c *Client) getCardsByIdentifiers(ctx context.Context, identifiers []CardIdentifier, offline bool) ([]*Card, error) {
+	found := make([]*Card, len(identifiers))
+	missing := []int{}
+	for i, identifier := range identifiers {
+		card, has, err := c.getLocalCardByIdentifier(identifier)
+		if err != nil {
+			return nil, err
+		}
+		if has {
+			found[i] = &card
+			continue
+		}
+		missing = append(missing, i)
+	}
+	if !offline && len(missing) != 0 {
+		log.Printf("mtgsdk - fetching %d cards by identifiers", len(missing))
+		err := c.fetchCollection(ctx, identifiers, missing, found)
+		var dnsError *net.DNSError
+		if errors.As(err, &dnsError) {
+			log.Println("mtgsdk - failed to connect to host, only using the card store")
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return found, nil
+}
+
+// Fetches the cards for the identifiers with the indexes in batches
+//
+// Fills the found slice with the fetched cards
+func (c *Client) fetchCollection(ctx context.Context, identifiers []CardIdentifier, indexes []int, found []*Card) error {
+	for start := 0; start < len(indexes); start += collectionBatchSize {
+		end := start + collectionBatchSize
+		if end > len(indexes) {
+			end = len(indexes)
+		}
+		batch := indexes[start:end]
+		body := collectionRequest{Identifiers: make([]CardIdentifier, len(batch))}
+		for i, index := range batch {
+			body.Identifiers[i] = identifiers[index]
+		}
+		resp, err := c.postJSON(ctx, c.apiURL+cardCollectionPath, body)
+		if err != nil {
+			return err
+		}
+		var collection collectionResponse
+		err = json.NewDecoder(resp.Body).Decode(&collection)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		err = c.saveCards(collection.Data)
+		if err != nil {
+			return err
+		}
+		alignCollection(identifiers, batch, collection, found)
+	}
+	return nil
+}
+
+// Fills the found slice with the cards of the collection response for the identifiers with the indexes
+//
+// The cards come in the order of the identifiers without the ones in not_found. If the amounts don't add up,
+// every card is matched against the whole batch instead
+func alignCollection(identifiers []CardIdentifier, batch []int, collection collectionResponse, found []*Card) {
+	notFound := map[CardIdentifier]int{}
+	for _, identifier := range collection.NotFound {
+		notFound[identifier]++
+	}
+	matched := []int{}
+	for _, index := range batch {
+		if notFound[identifiers[index]] > 0 {
+			notFound[identifiers[index]]--
+			continue
+		}
+		matched = append(matched, index)
+	}
+	if len(matched) == len(collection.Data) {
+		for i, card := range collection.Data {
+			card := card
+			found[matched[i]] = &card
+		}
+		return
+	}
+	log.Printf("mtgsdk - got %d cards for %d identifiers, matching the cards to the identifiers", len(collection.Data), len(matched))
+	for _, card := range collection.Data {
+		for _, index := range batch {
+			if found[index] == nil && identifiers[index].matches(card) {
+				card := card
+				found[index] = &card
+				break
+			}
+		}
+	}
+}
+
+// Returns the card for the identifier from the card store
+func (c *Client) getLocalCardByIdentifier(identifier CardIdentifier) (Card, bool, error) {
+	var cards []Card
+	var err error
+	switch {
+	case identifier.ID != "":
+		return c.store.Get(identifier.ID)
+	case identifier.OracleID != "":
+		cards, err = c.store.FindByOracleID(identifier.OracleID)
+	case identifier.Name != "":
+		cards, err = c.store.FindByName(identifier.Name)
+	case identifier.Set != "" && identifier.CollectorNumber != "":
+		cards, err = c.store.FindBySet(identifier.Set)
+	}
+	if err != nil {
+		return Card{}, false, err
+	}
+	card, found := pickPrinting(cards, identifier.matches)
+	return card, found, nil
+}
@@ -0,0 +1,58 @@
+package mtgsdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// Returns a fake collection endpoint that returns the cards for the names and reports the other names as not found
+func serveCollection(cards map[string]Card) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body collectionRequest
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response := collectionResponse{Data: []Card{}, NotFound: []CardIdentifier{}}
+		for _, identifier := range body.Identifiers {
+			if card, has := cards[identifier.Name]; has {
+				response.Data = append(response.Data, card)
+				continue
+			}
+			response.NotFound = append(response.NotFound, identifier)
+		}
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func TestGetCardsByIdentifiersAlignment(t *testing.T) {
+	client := newTestClient(t, serveCollection(map[string]Card{
+		// scryfall resolves the name without the diacritic, so the card doesn't match the identifier by name
+		"Jotun Grunt": {ID: "jotun-grunt", Name: "Jötun Grunt"},
+		"Sol Ring":    {ID: "sol-ring", Name: "Sol Ring"},
+		"Forest":      {ID: "forest", Name: "Forest"},
+	}))
+	identifiers := []CardIdentifier{
+		IdentifierByName("Jotun Grunt", ""),
+		IdentifierByName("Missing Card", ""),
+		IdentifierByName("Sol Ring", ""),
+		IdentifierByName("Forest", ""),
+	}
+	found, err := client.getCardsByIdentifiers(context.Background(), identifiers, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"jotun-grunt", "", "sol-ring", "forest"}
+	for i, card := range found {
+		id := ""
+		if card != nil {
+			id = card.ID
+		}
+		if id != expected[i] {
+			t.Errorf("identifier %s: expected card %q, got %q", identifiers[i].Name, expected[i], id)
+		}
+	}
+}
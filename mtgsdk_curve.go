@@ -0,0 +1,154 @@
+package mtgsdk
+
+import (
+	"sort"
+	"strings"
+)
+
+// A way to split the mana curve into groups
+type CurveGrouping int
+
+const (
+	GroupNone      CurveGrouping = iota // No groups
+	GroupByType                         // By card type (the groups of OrderType)
+	GroupByCasting                      // Permanents and spells
+)
+
+const (
+	GroupPermanents = "Permanent" // The group of the permanents in GroupByCasting
+	GroupSpells     = "Spell"     // The group of the instants and sorceries in GroupByCasting
+	GroupOther      = "Other"     // The group of the cards without any of the types in GroupByType
+)
+
+// The options of Deck.ManaCurve
+type CurveOptions struct {
+	Zones        []Zone        // The zones of the cards (the main deck if empty)
+	IncludeLands bool          // If true, the lands are counted
+	GroupBy      CurveGrouping // The groups of the curve
+}
+
+// The mana curve of a deck
+type ManaCurve struct {
+	Bars    map[float64]int            // The amounts of the cards without X in their cost (mana value -- amount)
+	Groups  map[string]map[float64]int // The bars of the groups (group -- mana value -- amount), nil if not grouped
+	XSpells map[float64]int            // The amounts of the cards with X in their cost (mana value with X = 0 -- amount)
+	Count   int                        // The amount of cards in Bars
+	XCount  int                        // The amount of cards in XSpells
+	Average float64                    // The average mana value of the cards in Bars
+	Median  float64                    // The median mana value of the cards in Bars
+}
+
+// Returns the mana values of the bars in ascending order
+//
+// Every whole mana value from 0 to the highest one is included, so the gaps in the curve are visible
+func (c ManaCurve) Values() []float64 {
+	return curveValues(c.Bars)
+}
+
+// Returns the mana values of the bars in ascending order, with the missing whole mana values filled in
+func curveValues(bars map[float64]int) []float64 {
+	max := 0.
+	has := map[float64]bool{}
+	result := []float64{}
+	for value := range bars {
+		has[value] = true
+		result = append(result, value)
+		if value > max {
+			max = value
+		}
+	}
+	for value := 0.; value <= max; value++ {
+		if !has[value] {
+			result = append(result, value)
+		}
+	}
+	sort.Float64s(result)
+	return result
+}
+
+// Returns true if the mana cost of the card has X in it
+func hasXCost(card Card) bool {
+	return strings.Contains(card.FrontFace().ManaCost, "{X}")
+}
+
+// Returns the group of the card
+func curveGroup(card Card, grouping CurveGrouping) string {
+	switch grouping {
+	case GroupByType:
+		rank := typeRank(card)
+		if rank == len(typeOrder) {
+			return GroupOther
+		}
+		return typeOrder[rank]
+	case GroupByCasting:
+		if card.IsPermanent() {
+			return GroupPermanents
+		}
+		return GroupSpells
+	}
+	return ""
+}
+
+// Returns the mana curve of the deck
+//
+// The lands are excluded unless IncludeLands is set. The cards with X in their cost are counted in XSpells
+// and aren't a part of the bars, the average and the median
+func (d Deck) ManaCurve(opts CurveOptions) ManaCurve {
+	zones := opts.Zones
+	if len(zones) == 0 {
+		zones = []Zone{ZoneMain}
+	}
+	result := ManaCurve{Bars: map[float64]int{}, XSpells: map[float64]int{}}
+	if opts.GroupBy != GroupNone {
+		result.Groups = map[string]map[float64]int{}
+	}
+	total := 0.
+	d.eachInZones(zones, func(card Card, amount int) {
+		if card.IsLand() && !opts.IncludeLands {
+			return
+		}
+		if hasXCost(card) {
+			result.XSpells[card.Cmc] += amount
+			result.XCount += amount
+			return
+		}
+		result.Bars[card.Cmc] += amount
+		result.Count += amount
+		total += card.Cmc * float64(amount)
+		if result.Groups != nil {
+			group := curveGroup(card, opts.GroupBy)
+			if _, has := result.Groups[group]; !has {
+				result.Groups[group] = map[float64]int{}
+			}
+			result.Groups[group][card.Cmc] += amount
+		}
+	})
+	if result.Count != 0 {
+		result.Average = total / float64(result.Count)
+		result.Median = curveMedian(result.Bars, result.Count)
+	}
+	return result
+}
+
+// Returns the median mana value of the bars
+func curveMedian(bars map[float64]int, count int) float64 {
+	values := []float64{}
+	for value := range bars {
+		values = append(values, value)
+	}
+	sort.Float64s(values)
+	// returns the mana value of the card with the index (starting from 0) in the sorted cards
+	at := func(index int) float64 {
+		for _, value := range values {
+			if index < bars[value] {
+				return value
+			}
+			index -= bars[value]
+		}
+		return 0
+	}
+	if count%2 == 1 {
+		return at(count / 2)
+	}
+	return (at(count/2-1) + at(count/2)) / 2
+}
@@ -0,0 +1,73 @@
+package mtgsdk
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Returns the deck of the mana curve tests
+func curveTestDeck() *Deck {
+	deck := CreateDeck("curve")
+	for _, test := range []struct {
+		card   Card
+		amount int
+	}{
+		{Card{ID: "forest", Name: "Forest", TypeLine: "Basic Land — Forest"}, 10},
+		{Card{ID: "sol-ring", Name: "Sol Ring", ManaCost: "{1}", Cmc: 1, TypeLine: "Artifact"}, 1},
+		{Card{ID: "elves", Name: "Llanowar Elves", ManaCost: "{G}", Cmc: 1, TypeLine: "Creature — Elf Druid"}, 2},
+		{Card{ID: "bolt", Name: "Lightning Bolt", ManaCost: "{R}", Cmc: 1, TypeLine: "Instant"}, 1},
+		{Card{ID: "counterspell", Name: "Counterspell", ManaCost: "{U}{U}", Cmc: 2, TypeLine: "Instant"}, 2},
+		{Card{ID: "shivan", Name: "Shivan Dragon", ManaCost: "{4}{R}{R}", Cmc: 6, TypeLine: "Creature — Dragon"}, 2},
+		{Card{ID: "fireball", Name: "Fireball", ManaCost: "{X}{R}", Cmc: 1, TypeLine: "Sorcery"}, 1},
+		{Card{ID: "stonecoil", Name: "Stonecoil Serpent", ManaCost: "{X}", Cmc: 0, TypeLine: "Artifact Creature — Snake"}, 1},
+	} {
+		card := test.card
+		deck.AddCard(&card, test.amount)
+	}
+	return deck
+}
+
+func TestManaCurve(t *testing.T) {
+	curve := curveTestDeck().ManaCurve(CurveOptions{})
+	if expected := map[float64]int{1: 4, 2: 2, 6: 2}; !reflect.DeepEqual(curve.Bars, expected) {
+		t.Fatalf("expected the bars %v without the lands and the X spells, got %v", expected, curve.Bars)
+	}
+	if expected := map[float64]int{0: 1, 1: 1}; !reflect.DeepEqual(curve.XSpells, expected) {
+		t.Fatalf("expected the X spells %v, got %v", expected, curve.XSpells)
+	}
+	if curve.Count != 8 || curve.XCount != 2 {
+		t.Fatalf("expected 8 cards and 2 X spells, got %d and %d", curve.Count, curve.XCount)
+	}
+	// 1 1 1 1 2 2 6 6
+	if curve.Average != 2.5 || curve.Median != 1.5 {
+		t.Fatalf("expected the average 2.5 and the median 1.5, got %v and %v", curve.Average, curve.Median)
+	}
+	if curve.Groups != nil {
+		t.Fatalf("expected no groups, got %v", curve.Groups)
+	}
+	if expected := []float64{0, 1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(curve.Values(), expected) {
+		t.Fatalf("expected the values %v, got %v", expected, curve.Values())
+	}
+}
+
+func TestManaCurveWithLands(t *testing.T) {
+	curve := curveTestDeck().ManaCurve(CurveOptions{IncludeLands: true})
+	if curve.Bars[0] != 10 || curve.Count != 18 {
+		t.Fatalf("expected the 10 lands in the 0 bar, got %v", curve.Bars)
+	}
+	// 10 lands and 8 spells
+	if curve.Median != 0 || curve.Average != 20./18 {
+		t.Fatalf("expected the median 0 and the average %v, got %v and %v", 20./18, curve.Median, curve.Average)
+	}
+}
+
+func TestManaCurveGroupByCasting(t *testing.T) {
+	curve := curveTestDeck().ManaCurve(CurveOptions{GroupBy: GroupByCasting})
+	expected := map[string]map[float64]int{
+		GroupPermanents: {1: 3, 6: 2},
+		GroupSpells:     {1: 1, 2: 2},
+	}
+	if !reflect.DeepEqual(curve.Groups, expected) {
+		t.Fatalf("expected the groups %v, got %v", expected, curve.Groups)
+	}
+}
@@ -1,9 +1,11 @@
 package mtgsdk
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/GrandOichii/colorwrapper"
@@ -11,7 +13,10 @@ import (
 
 // A struct of deck statistics
 type DeckStat struct {
-	CMCBars        map[float64]int // mana values
+	CMCBars        map[float64]int // The mana curve without the lands and the X spells (mana value -- amount)
+	XSpellCount    int             // The amount of cards with X in their cost
+	AverageCMC     float64         // The average mana value of the cards in CMCBars
+	MedianCMC      float64         // The median mana value of the cards in CMCBars
 	RampCount      int             // The amount of ramp cards
 	CardDrawCount  int             // The amount of card draw
 	BoardWipeCount int             // The amount of board wipes
@@ -27,13 +32,10 @@ func (d DeckStat) Print() error {
 	fmt.Printf("\tBoard wipes: %d\n", d.BoardWipeCount)
 	fmt.Printf("\tRemoval: %d\n", d.RemovalCount)
 	fmt.Printf("\tLands: %d\n", d.LandCount)
-	keys := []float64{0., 1., 2., 3., 4., 5., 6., 7., 8., 9., 10.}
-	for _, key := range keys {
-		value, has := d.CMCBars[key]
-		if !has {
-			value = 0
-		}
-		s := strings.TrimRight(strings.Repeat("# ", value), " ")
+	fmt.Printf("\tX spells: %d\n", d.XSpellCount)
+	fmt.Printf("\tAverage mana value: %.2f (median %v)\n", d.AverageCMC, d.MedianCMC)
+	for _, key := range curveValues(d.CMCBars) {
+		s := strings.TrimRight(strings.Repeat("# ", d.CMCBars[key]), " ")
 		colored, err := colorwrapper.GetColored("normal-cyan", s)
 		if err != nil {
 			return err
@@ -45,9 +47,11 @@ func (d DeckStat) Print() error {
 
 // A deck struct
 type Deck struct {
-	Name    string         // The name of the deck
-	cards   []Card         // The cards
-	amounts map[string]int // The map of the amounts (card.id -- amount)
+	Name  string             // The name of the deck
+	Tags  []string           // The tags of the deck
+	Notes string             // The notes of the deck
+	zones map[Zone]*deckZone // The zones of the deck (commander, main deck, sideboard, ...)
+	order DeckOrder          // The order of the cards
 }
 
 // Creates a new deck
@@ -58,42 +62,114 @@ func CreateDeck(name string) *Deck {
 }
 
 // Reads the deck from the specified path
-func ReadDeck(path string) (Deck, error) {
-	contents, err := os.ReadFile(path)
+func (c *Client) ReadDeck(path string) (Deck, error) {
+	return c.ReadDeckContext(context.Background(), path)
+}
+
+// Same as ReadDeck, but uses the context for the network requests
+func (c *Client) ReadDeckContext(ctx context.Context, path string) (Deck, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Deck{}, err
+	}
+	defer file.Close()
+	return c.ReadDeckFromContext(ctx, file)
+}
+
+// Reads the deck from the reader, detecting the file format from the content (see DetectFileFormat)
+//
+// Lines with a set code and a collector number (1 Sol Ring (C21) 263 *F*) are looked up by the exact printing.
+// The cards are added to the zones of their sections
+func (c *Client) ReadDeckFrom(r io.Reader) (Deck, error) {
+	return c.ReadDeckFromContext(context.Background(), r)
+}
+
+// Same as ReadDeckFrom, but uses the context for the network requests
+func (c *Client) ReadDeckFromContext(ctx context.Context, r io.Reader) (Deck, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Deck{}, err
+	}
+	return c.importDeck(ctx, data, DetectFileFormat(data))
+}
+
+// Creates a deck from the decklist lines, cards that aren't found are reported as ParseErrors
+func (c *Client) deckFromLines(ctx context.Context, lines []DecklistLine) (Deck, error) {
+	identifiers := make([]CardIdentifier, len(lines))
+	for i, line := range lines {
+		identifiers[i] = line.identifier()
+	}
+	// look up all the cards at once
+	cards, err := c.getCardsByIdentifiers(ctx, identifiers, false)
 	if err != nil {
 		return Deck{}, err
 	}
-	text := string(contents)
-	lines := strings.Split(text, "\n")
 	result := Deck{}
-	for _, line := range lines {
-		words := strings.Split(line, " ")
-		amount := words[0]
-		cardName := strings.Join(words[1:], " ")
-		fcards, err := GetCards(map[string]string{CardNameKey: cardName}, false)
-		if err != nil {
-			return Deck{}, err
-		}
-		a, err := strconv.Atoi(amount)
-		if err != nil {
-			return Deck{}, err
+	errs := []error{}
+	for i, card := range cards {
+		line := lines[i]
+		if card == nil {
+			errs = append(errs, &ParseError{Line: line.Line, Text: line.Name, Err: fmt.Errorf("mtgsdk - card %s not found: %w", line.Name, ErrNotFound)})
+			continue
 		}
-		result.AddCard(&fcards[0], a)
+		result.AddToZoneWithFinish(line.Zone, card, line.Amount, line.Finish)
+	}
+	if len(errs) != 0 {
+		return Deck{}, joinErrors(errs...)
 	}
 	return result, nil
 }
 
-// Adds a card to the deck
+// Adds a card to the main deck
 func (d *Deck) AddCard(card *Card, amount int) {
-	if d.amounts == nil {
-		d.amounts = map[string]int{}
-		d.cards = make([]Card, 0)
+	d.AddToZone(ZoneMain, card, amount)
+}
+
+// Adds a printing of the card with the finish to the main deck
+//
+// The copies of different finishes are separate entries, so 1 Sol Ring and 1 foil Sol Ring stay apart
+func (d *Deck) AddCardWithFinish(card *Card, amount int, finish Finish) {
+	d.AddToZoneWithFinish(ZoneMain, card, amount, finish)
+}
+
+// Returns the finish of the card in the deck (nonfoil if the card is not in the deck)
+//
+// If the deck has copies of the card with several finishes, returns the first one (see ZoneEntries for all of them)
+func (d Deck) GetFinish(cardID string) Finish {
+	for _, zone := range zoneOrder {
+		for _, entry := range d.getZone(zone).entries {
+			if entry.card.ID == cardID {
+				return entry.finish
+			}
+		}
 	}
-	d.cards = append(d.cards, *card)
-	if _, has := d.amounts[card.ID]; !has {
-		d.amounts[card.ID] = 0
+	return FinishNonfoil
+}
+
+// Returns the printings of the main deck with their amounts and finishes
+func (d Deck) Entries() []DeckEntry {
+	return d.ZoneEntries(ZoneMain)
+}
+
+// Sets the commanders of the deck (the oathbreaker and the signature spell in oathbreaker)
+//
+// Replaces the cards in the commander zone
+func (d *Deck) SetCommanders(commanders ...Card) {
+	commanderZone := d.zone(ZoneCommander)
+	commanderZone.entries = nil
+	for _, commander := range commanders {
+		commanderZone.add(commander, FinishNonfoil, 1)
 	}
-	d.amounts[card.ID] += amount
+}
+
+// Returns the commanders of the deck
+func (d Deck) GetCommanders() []Card {
+	return d.getZone(ZoneCommander).uniqueCards()
+}
+
+// Adds a card to the sideboard of the deck
+func (d *Deck) AddSideboardCard(card *Card, amount int) {
+	d.AddToZone(ZoneSideboard, card, amount)
 }
 
 // Adds a card if it's not already in the deck
@@ -109,73 +185,143 @@ func (d *Deck) AddSingletonCard(card *Card) bool {
 }
 
 // Saves the deck the specified path
+//
+// The cards are saved with their printings (1 Sol Ring (C21) 263 *F*). If the deck has cards outside of the main deck,
+// every zone is saved under its section header
 func (d Deck) Save(path string) error {
-	resultText := ""
-	for i, card := range d.cards {
-		resultText += fmt.Sprint((d.amounts[card.ID])) + " " + card.Name
-		if i != len(d.cards)-1 {
-			resultText += "\n"
+	var buffer bytes.Buffer
+	// the text writer is called directly, so a writer registered for the text format can't change the saved files
+	err := writeText(&buffer, d)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, buffer.Bytes(), 0755)
+}
+
+// Returns the non-empty zones of the deck in the order they are saved
+func (d Deck) usedZones() []Zone {
+	result := []Zone{}
+	for _, zone := range zoneOrder {
+		if len(d.getZone(zone).entries) != 0 {
+			result = append(result, zone)
 		}
 	}
-	return os.WriteFile(path, []byte(resultText), 0755)
+	return result
 }
 
-// Returns a slice of unique cards of the deck
+// Returns a slice of unique cards of the main deck (in the order of the deck)
 func (d Deck) GetUniqueCards() []Card {
-	return d.cards
+	return d.orderedCards(ZoneMain)
 }
 
-// Returns the number of card instances in deck
+// The zones that count as the deck (used by Count, Contains, TotalCount, RemoveCard and SetQuantity)
+var countedZones = []Zone{ZoneMain, ZoneCommander}
+
+// Returns the number of card instances in the main deck and the commander zone
 func (d Deck) Count(cardID string) int {
-	result, has := d.amounts[cardID]
-	if !has {
-		return 0
+	result := 0
+	for _, zone := range countedZones {
+		result += d.CountInZone(zone, cardID)
 	}
 	return result
 }
 
+// Returns true if the card is in the main deck or the commander zone
+func (d Deck) Contains(cardID string) bool {
+	return d.Count(cardID) != 0
+}
+
+// Returns the amount of cards in the main deck and the commander zone
+func (d Deck) TotalCount() int {
+	result := 0
+	for _, zone := range countedZones {
+		result += d.ZoneSize(zone)
+	}
+	return result
+}
+
+// Removes all the copies of the card from the main deck and the commander zone
+//
+// Returns true if the card was in the deck
+func (d *Deck) RemoveCard(cardID string) bool {
+	removed := 0
+	for _, zone := range countedZones {
+		removed += d.RemoveFromZone(zone, cardID, d.CountInZone(zone, cardID))
+	}
+	return removed != 0
+}
+
+// Sets the amount of copies of the card in the main deck and the commander zone
+//
+// Removes the card if amount is not positive. If the card has several entries (finishes or zones), the amount is set for the first one
+// (the main deck first) and the others are removed. Returns an error if the card is not in the deck
+func (d *Deck) SetQuantity(cardID string, amount int) error {
+	if !d.Contains(cardID) {
+		return fmt.Errorf("mtgsdk - card %s is not in the deck", cardID)
+	}
+	// the first entry keeps its place in the insertion order
+	found := false
+	for _, zone := range countedZones {
+		z := d.zone(zone)
+		kept := z.entries[:0]
+		for _, entry := range z.entries {
+			if entry.card.ID == cardID {
+				if found || amount <= 0 {
+					continue
+				}
+				found = true
+				entry.amount = amount
+			}
+			kept = append(kept, entry)
+		}
+		z.entries = kept
+	}
+	return nil
+}
+
 // Prints the deck out to the console
 func (d Deck) Print() error {
 	fmt.Printf("Deck %s\n", d.Name)
-	for _, card := range d.cards {
-		amount, has := d.amounts[card.ID]
-		if !has {
-			return fmt.Errorf("mtgsdk - deck.amounts doesn't contain card %s", card.Name)
+	used := d.usedZones()
+	for _, zone := range used {
+		if zone != ZoneMain || len(used) > 1 {
+			fmt.Printf("%s (%d)\n", zone, d.ZoneSize(zone))
+		}
+		for _, entry := range d.ZoneEntries(zone) {
+			fmt.Printf("%d %s\n", entry.Amount, entry.Card.Name)
 		}
-		fmt.Printf("%d %s\n", amount, card.Name)
 	}
 	return nil
 }
 
-// Returns a slice of mana values (index - mana value, value - count)
-func (d Deck) getCMCBars() (map[float64]int, error) {
-	result := map[float64]int{}
-	for _, card := range d.cards {
-		amount, has := d.amounts[card.ID]
-		if !has {
-			return nil, fmt.Errorf("mtgsdk - deck.amounts doesn't contain card %s", card.Name)
-		}
-		if _, has := result[card.Cmc]; !has {
-			result[card.Cmc] = 0
+// Calls f for every card of the zones with its amount
+func (d Deck) eachInZones(zones []Zone, f func(card Card, amount int)) {
+	for _, zone := range zones {
+		for _, entry := range d.ZoneEntries(zone) {
+			f(entry.Card, entry.Amount)
 		}
-		result[card.Cmc] += amount
 	}
-	return result, nil
 }
 
-// Returns the statistics of the deck
+// Returns the statistics of the main deck (the commanders are not counted)
 func (d Deck) GetStats() (*DeckStat, error) {
+	return d.getStats([]Zone{ZoneMain}), nil
+}
+
+// Returns the statistics of the main deck and the commanders
+func (d Deck) GetStatsWithCommander() (*DeckStat, error) {
+	return d.getStats([]Zone{ZoneCommander, ZoneMain}), nil
+}
+
+// Returns the statistics of the cards in the zones
+func (d Deck) getStats(zones []Zone) *DeckStat {
 	result := DeckStat{}
-	var err error
-	result.CMCBars, err = d.getCMCBars()
-	if err != nil {
-		return nil, err
-	}
-	for _, card := range d.cards {
-		amount, has := d.amounts[card.ID]
-		if !has {
-			return nil, fmt.Errorf("mtgsdk - deck.amounts doesn't contain card %s", card.Name)
-		}
+	curve := d.ManaCurve(CurveOptions{Zones: zones})
+	result.CMCBars = curve.Bars
+	result.XSpellCount = curve.XCount
+	result.AverageCMC = curve.Average
+	result.MedianCMC = curve.Median
+	d.eachInZones(zones, func(card Card, amount int) {
 		result.CardCount += amount
 		if card.IsRamp() {
 			result.RampCount += amount
@@ -192,8 +338,8 @@ func (d Deck) GetStats() (*DeckStat, error) {
 		if card.IsLand() {
 			result.LandCount += amount
 		}
-	}
-	return &result, nil
+	})
+	return &result
 }
 
 // Reccomends basic lands for the deck
@@ -231,16 +377,12 @@ func (d Deck) ReccomendBasicLands(count int) (map[string]int, error) {
 		"R": 0,
 		"G": 0,
 	}
-	for _, card := range d.cards {
-		amount, has := d.amounts[card.ID]
-		if !has {
-			return nil, fmt.Errorf("mtgsdk - deck.amounts doesn't contain card %s", card.Name)
-		}
+	d.eachInZones([]Zone{ZoneCommander, ZoneMain}, func(card Card, amount int) {
 		pipCount := card.CountColorPips()
 		for pip, pipc := range pipCount {
 			totalPipCount[pip] += pipc * amount
 		}
-	}
+	})
 	allPips := 0
 	for _, c := range totalPipCount {
 		allPips += c
@@ -0,0 +1,171 @@
+package mtgsdk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Matches a deck line: 1 Sol Ring (C21) 263 *F* (the amount can be written as 1x or omitted)
+	deckLineRe = regexp.MustCompile(`^(?:(\d+)[xX]?\s+)?(.+?)(?:\s+\(([A-Za-z0-9]+)\)(?:\s+([A-Za-z0-9★†-]+))?)?(?:\s+\*([FE])\*)?$`)
+
+	// The headers of the decklist sections (lower case header -- zone)
+	sectionHeaders = map[string]Zone{
+		"commander":   ZoneCommander,
+		"commanders":  ZoneCommander,
+		"companion":   ZoneCompanion,
+		"companions":  ZoneCompanion,
+		"deck":        ZoneMain,
+		"main":        ZoneMain,
+		"mainboard":   ZoneMain,
+		"sideboard":   ZoneSideboard,
+		"maybeboard":  ZoneMaybeboard,
+		"maybe":       ZoneMaybeboard,
+		"considering": ZoneMaybeboard,
+	}
+
+	// The prefix of sideboard lines in mtgo lists (SB: 2 Duress)
+	sideboardLinePrefix = "SB:"
+
+	// The suffix of commander lines in tappedout lists (1x Krenko, Mob Boss *CMDR*)
+	commanderLineSuffix = "*CMDR*"
+)
+
+// A card line of a decklist
+type DecklistLine struct {
+	Line            int    // The number of the line (starting from 1)
+	Zone            Zone   // The zone of the section of the line
+	Amount          int    // The amount of copies
+	Name            string // The name of the card
+	SetCode         string // The set code (empty if not specified)
+	CollectorNumber string // The collector number (empty if not specified)
+	SetName         string // The set name, resolved to the set code when the deck is imported (empty if not specified)
+	Finish          Finish // The finish of the copies
+	ID              string // The scryfall id of the card (empty if not specified)
+}
+
+// Returns the identifier of the card of the line
+//
+// Lines with a scryfall id or a set code and a collector number identify the exact printing
+func (l DecklistLine) identifier() CardIdentifier {
+	if l.ID != "" {
+		return IdentifierByID(l.ID)
+	}
+	if l.SetCode != "" && l.CollectorNumber != "" {
+		return IdentifierByPrinting(l.SetCode, l.CollectorNumber)
+	}
+	return IdentifierByName(l.Name, l.SetCode)
+}
+
+// An error in a line of a decklist
+type ParseError struct {
+	Line int    // The number of the line (starting from 1)
+	Text string // The text of the line
+	Err  error  // The cause of the error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("mtgsdk - line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parses a deck line (1 Sol Ring, 1x Sol Ring (C21), 1 Sol Ring (C21) 263 *F*)
+//
+// Lines without an amount have a single copy
+func parseDeckLine(line string) (DecklistLine, error) {
+	match := deckLineRe.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return DecklistLine{}, fmt.Errorf("mtgsdk - can't parse deck line %q", line)
+	}
+	amount := 1
+	if match[1] != "" {
+		var err error
+		amount, err = strconv.Atoi(match[1])
+		if err != nil {
+			return DecklistLine{}, err
+		}
+	}
+	if amount <= 0 {
+		return DecklistLine{}, fmt.Errorf("mtgsdk - deck line %q has a non-positive amount", line)
+	}
+	result := DecklistLine{
+		Amount:          amount,
+		Name:            match[2],
+		SetCode:         strings.ToLower(match[3]),
+		CollectorNumber: match[4],
+		Finish:          FinishNonfoil,
+	}
+	switch match[5] {
+	case "F":
+		result.Finish = FinishFoil
+	case "E":
+		result.Finish = FinishEtched
+	}
+	return result, nil
+}
+
+// Returns the zone if the line is a section header (Sideboard, Sideboard:, // Sideboard)
+func parseSectionHeader(line string) (Zone, bool) {
+	header := strings.TrimSpace(strings.TrimPrefix(line, "//"))
+	header = strings.ToLower(strings.TrimSuffix(header, ":"))
+	zone, has := sectionHeaders[header]
+	return zone, has
+}
+
+// Parses the decklist
+//
+// Supports Commander, Companion, Deck, Sideboard and Maybeboard section headers, // and # comments,
+// amounts written as 4 or 4x, SB: prefixes, *CMDR* suffixes and windows line endings. Lines before the first header are in the main deck.
+// All the invalid lines are reported as a MultiError of ParseErrors, along with the lines that were parsed
+func ParseDecklist(r io.Reader) ([]DecklistLine, error) {
+	result := []DecklistLine{}
+	errs := []error{}
+	zone := ZoneMain
+	scanner := bufio.NewScanner(r)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if text == "" {
+			continue
+		}
+		if z, isHeader := parseSectionHeader(text); isHeader {
+			zone = z
+			continue
+		}
+		if strings.HasPrefix(text, "//") || strings.HasPrefix(text, "#") {
+			continue
+		}
+		lineZone := zone
+		if strings.HasPrefix(strings.ToUpper(text), sideboardLinePrefix) {
+			lineZone = ZoneSideboard
+			text = strings.TrimSpace(text[len(sideboardLinePrefix):])
+		}
+		if strings.HasSuffix(strings.ToUpper(text), commanderLineSuffix) {
+			lineZone = ZoneCommander
+			text = strings.TrimSpace(text[:len(text)-len(commanderLineSuffix)])
+		}
+		line, err := parseDeckLine(text)
+		if err != nil {
+			errs = append(errs, &ParseError{Line: number, Text: text, Err: err})
+			continue
+		}
+		line.Line = number
+		line.Zone = lineZone
+		result = append(result, line)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return result, nil
+	}
+	return result, &MultiError{Errors: errs}
+}
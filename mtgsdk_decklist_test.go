@@ -0,0 +1,69 @@
+package mtgsdk
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDecklist(t *testing.T) {
+	text := strings.Join([]string{
+		"\ufeff// a comment",
+		"1 Sol Ring",
+		"# another comment",
+		"4x Lightning Bolt (M10)",
+		"1 Fire // Ice (MH2) 290",
+		"",
+		"Commander",
+		"1 Atraxa, Praetors' Voice (2X2) 190 *F*",
+		"// Sideboard",
+		"2X Duress (M19) 94",
+		"1 Sol Ring (C21) 263 *E*",
+		"Maybeboard:",
+		"Brainstorm",
+		"SB: 1 Negate",
+	}, "\r\n")
+	lines, err := ParseDecklist(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []DecklistLine{
+		{Line: 2, Zone: ZoneMain, Amount: 1, Name: "Sol Ring", Finish: FinishNonfoil},
+		{Line: 4, Zone: ZoneMain, Amount: 4, Name: "Lightning Bolt", SetCode: "m10", Finish: FinishNonfoil},
+		{Line: 5, Zone: ZoneMain, Amount: 1, Name: "Fire // Ice", SetCode: "mh2", CollectorNumber: "290", Finish: FinishNonfoil},
+		{Line: 8, Zone: ZoneCommander, Amount: 1, Name: "Atraxa, Praetors' Voice", SetCode: "2x2", CollectorNumber: "190", Finish: FinishFoil},
+		{Line: 10, Zone: ZoneSideboard, Amount: 2, Name: "Duress", SetCode: "m19", CollectorNumber: "94", Finish: FinishNonfoil},
+		{Line: 11, Zone: ZoneSideboard, Amount: 1, Name: "Sol Ring", SetCode: "c21", CollectorNumber: "263", Finish: FinishEtched},
+		{Line: 13, Zone: ZoneMaybeboard, Amount: 1, Name: "Brainstorm", Finish: FinishNonfoil},
+		{Line: 14, Zone: ZoneSideboard, Amount: 1, Name: "Negate", Finish: FinishNonfoil},
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Fatalf("expected the lines\n%v\ngot\n%v", expected, lines)
+	}
+}
+
+func TestParseDecklistErrors(t *testing.T) {
+	text := "1 Sol Ring\n0 Forest\n2 Duress\n0x Island\n"
+	lines, err := ParseDecklist(strings.NewReader(text))
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("expected a MultiError, got %v", err)
+	}
+	if len(multiErr.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %v", multiErr.Errors)
+	}
+	for i, expected := range []int{2, 4} {
+		var parseErr *ParseError
+		if !errors.As(multiErr.Errors[i], &parseErr) {
+			t.Fatalf("expected a ParseError, got %v", multiErr.Errors[i])
+		}
+		if parseErr.Line != expected {
+			t.Fatalf("expected an error on line %d, got line %d", expected, parseErr.Line)
+		}
+	}
+	// the valid lines are still parsed
+	if len(lines) != 2 || lines[0].Name != "Sol Ring" || lines[1].Name != "Duress" || lines[1].Line != 3 {
+		t.Fatalf("expected the valid lines, got %v", lines)
+	}
+}
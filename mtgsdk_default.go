@@ -0,0 +1,255 @@
+package mtgsdk
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	defaultClient     *Client   // the client used by the package-level functions
+	defaultClientErr  error     // the error from creating the default client
+	defaultClientOnce sync.Once // guards the creation of the default client
+)
+
+// Returns the default client, creating it on first use
+//
+// The default client stores its data in the mtgsdk-data appdata folder
+func DefaultClient() (*Client, error) {
+	defaultClientOnce.Do(func() {
+		defaultClient, defaultClientErr = NewClient()
+	})
+	return defaultClient, defaultClientErr
+}
+
+// Fetches the cards from the scryfall api using the default client
+func FetchCards(params map[string]string) ([]Card, error) {
+	return FetchCardsContext(context.Background(), params)
+}
+
+// Same as FetchCards, but uses the context for the network requests
+func FetchCardsContext(ctx context.Context, params map[string]string) ([]Card, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.FetchCardsContext(ctx, params)
+}
+
+// Returns the cards stored locally by the default client
+func GetCardsOffline(params map[string]string) ([]Card, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetCardsOffline(params)
+}
+
+// Searches the cards online using the default client, if fails, searches for them locally
+func GetCards(params map[string]string, offline bool) ([]Card, error) {
+	return GetCardsContext(context.Background(), params, offline)
+}
+
+// Same as GetCards, but uses the context for the network requests
+func GetCardsContext(ctx context.Context, params map[string]string, offline bool) ([]Card, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetCardsContext(ctx, params, offline)
+}
+
+// Downloads the card images that match the params using the default client
+func DownloadCardImages(params map[string]string, deckPath string, outPath string, quality ImageQuality, offline bool) error {
+	return DownloadCardImagesContext(context.Background(), params, deckPath, outPath, quality, offline)
+}
+
+// Same as DownloadCardImages, but uses the context for the network requests
+func DownloadCardImagesContext(ctx context.Context, params map[string]string, deckPath string, outPath string, quality ImageQuality, offline bool) error {
+	c, err := DefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.DownloadCardImagesContext(ctx, params, deckPath, outPath, quality, offline)
+}
+
+// Returns the card with the specified id using the default client
+func GetCard(id string) (Card, error) {
+	return GetCardContext(context.Background(), id)
+}
+
+// Same as GetCard, but uses the context for the network requests
+func GetCardContext(ctx context.Context, id string) (Card, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return Card{}, err
+	}
+	return c.GetCardContext(ctx, id)
+}
+
+// Returns the map of basic lands using the default client
+func GetBasicLands(offline bool) (map[string]Card, error) {
+	return GetBasicLandsContext(context.Background(), offline)
+}
+
+// Same as GetBasicLands, but uses the context for the network requests
+func GetBasicLandsContext(ctx context.Context, offline bool) (map[string]Card, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetBasicLandsContext(ctx, offline)
+}
+
+// Reads the deck from the specified path using the default client
+func ReadDeck(path string) (Deck, error) {
+	return ReadDeckContext(context.Background(), path)
+}
+
+// Same as ReadDeck, but uses the context for the network requests
+func ReadDeckContext(ctx context.Context, path string) (Deck, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return Deck{}, err
+	}
+	return c.ReadDeckContext(ctx, path)
+}
+
+// Returns a slice of all commander staple cards using the default client
+func GetEDHRECStaples(offline bool) ([]Card, error) {
+	return GetEDHRECStaplesContext(context.Background(), offline)
+}
+
+// Same as GetEDHRECStaples, but uses the context for the network requests
+func GetEDHRECStaplesContext(ctx context.Context, offline bool) ([]Card, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetEDHRECStaplesContext(ctx, offline)
+}
+
+// Downloads the card image to the specified path using the default client
+func (c Card) DownloadImage(outPath string, quality ImageQuality) error {
+	return c.DownloadImageContext(context.Background(), outPath, quality)
+}
+
+// Same as DownloadImage, but uses the context for the network requests
+func (c Card) DownloadImageContext(ctx context.Context, outPath string, quality ImageQuality) error {
+	client, err := DefaultClient()
+	if err != nil {
+		return err
+	}
+	return client.DownloadImageContext(ctx, c, outPath, quality)
+}
+
+// Returns the map of card ids and their synergies using the default client
+func (c Card) GetReccomendations(synergy int, offline bool) (map[*Card]int, error) {
+	return c.GetReccomendationsContext(context.Background(), synergy, offline)
+}
+
+// Same as GetReccomendations, but uses the context for the network requests
+func (c Card) GetReccomendationsContext(ctx context.Context, synergy int, offline bool) (map[*Card]int, error) {
+	client, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.GetReccomendationsContext(ctx, c, synergy, offline)
+}
+
+// Generates a commander deck for a card using the default client
+func (c Card) GenerateCommanderDeck(params map[string]interface{}, offline bool) (*Deck, error) {
+	return c.GenerateCommanderDeckContext(context.Background(), params, offline)
+}
+
+// Same as GenerateCommanderDeck, but uses the context for the network requests
+func (c Card) GenerateCommanderDeckContext(ctx context.Context, params map[string]interface{}, offline bool) (*Deck, error) {
+	client, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.GenerateCommanderDeckContext(ctx, c, params, offline)
+}
+
+// Fetches at most limit cards from the scryfall api using the default client
+func FetchCardsLimit(params map[string]string, limit int) ([]Card, error) {
+	return FetchCardsLimitContext(context.Background(), params, limit)
+}
+
+// Same as FetchCardsLimit, but uses the context for the network requests
+func FetchCardsLimitContext(ctx context.Context, params map[string]string, limit int) ([]Card, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.FetchCardsLimitContext(ctx, params, limit)
+}
+
+// Fetches the cards that match the query from the scryfall api using the default client
+func FetchQuery(q QueryNode) ([]Card, error) {
+	return FetchQueryContext(context.Background(), q)
+}
+
+// Same as FetchQuery, but uses the context for the network requests
+func FetchQueryContext(ctx context.Context, q QueryNode) ([]Card, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.FetchQueryContext(ctx, q)
+}
+
+// Searches the cards that match the query using the default client
+func GetCardsQuery(q QueryNode, offline bool) ([]Card, error) {
+	return GetCardsQueryContext(context.Background(), q, offline)
+}
+
+// Same as GetCardsQuery, but uses the context for the network requests
+func GetCardsQueryContext(ctx context.Context, q QueryNode, offline bool) ([]Card, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetCardsQueryContext(ctx, q, offline)
+}
+
+// Searches the cards with scryfall search syntax using the default client
+func Search(query string, offline bool) ([]Card, error) {
+	return SearchContext(context.Background(), query, offline)
+}
+
+// Same as Search, but uses the context for the network requests
+func SearchContext(ctx context.Context, query string, offline bool) ([]Card, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.SearchContext(ctx, query, offline)
+}
+
+// Imports the cards from a downloaded scryfall bulk data file using the default client
+func ImportBulkFile(path string, progress ProgressFunc) (int, error) {
+	return ImportBulkFileContext(context.Background(), path, progress)
+}
+
+// Same as ImportBulkFile, but uses the context for the network requests
+func ImportBulkFileContext(ctx context.Context, path string, progress ProgressFunc) (int, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return 0, err
+	}
+	return c.ImportBulkFileContext(ctx, path, progress)
+}
+
+// Returns the card with the name using the default client
+func ResolveName(name string, mode NameMode) (Card, error) {
+	return ResolveNameContext(context.Background(), name, mode)
+}
+
+// Same as ResolveName, but uses the context for the network requests
+func ResolveNameContext(ctx context.Context, name string, mode NameMode) (Card, error) {
+	c, err := DefaultClient()
+	if err != nil {
+		return Card{}, err
+	}
+	return c.ResolveNameContext(ctx, name, mode)
+}
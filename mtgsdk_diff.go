@@ -0,0 +1,139 @@
+package mtgsdk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A change of the amount of copies of a card in a deck zone
+type CardChange struct {
+	Name     string `json:"name"`      // The name of the card
+	OracleID string `json:"oracle_id"` // The oracle id of the card
+	Before   int    `json:"before"`    // The amount of copies in the old deck
+	After    int    `json:"after"`     // The amount of copies in the new deck
+}
+
+// Returns the difference of the amounts (negative if copies were removed)
+func (c CardChange) Delta() int {
+	return c.After - c.Before
+}
+
+// The changes of a deck zone
+type ZoneDiff struct {
+	Added   []CardChange `json:"added,omitempty"`   // The cards that weren't in the zone
+	Removed []CardChange `json:"removed,omitempty"` // The cards that are no longer in the zone
+	Changed []CardChange `json:"changed,omitempty"` // The cards with a different amount of copies
+}
+
+// Returns true if the zone didn't change
+func (z ZoneDiff) Empty() bool {
+	return len(z.Added) == 0 && len(z.Removed) == 0 && len(z.Changed) == 0
+}
+
+// The changes between two decks
+type DeckDiff struct {
+	From  string            `json:"from"`  // The name of the old deck
+	To    string            `json:"to"`    // The name of the new deck
+	Zones map[Zone]ZoneDiff `json:"zones"` // The changes of the zones (zones without changes are missing)
+}
+
+// Returns true if the decks have the same cards
+func (d DeckDiff) Empty() bool {
+	return len(d.Zones) == 0
+}
+
+// Returns the diff as a unified diff (-2 Duress, +4 Lightning Bolt), quantity changes are shown as a removed and an added line
+func (d DeckDiff) String() string {
+	from, to := d.From, d.To
+	if from == "" {
+		from = "a"
+	}
+	if to == "" {
+		to = "b"
+	}
+	lines := []string{"--- " + from, "+++ " + to}
+	for _, zone := range zoneOrder {
+		diff, has := d.Zones[zone]
+		if !has {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("@@ %s @@", zone))
+		for _, change := range diff.Removed {
+			lines = append(lines, fmt.Sprintf("-%d %s", change.Before, change.Name))
+		}
+		for _, change := range diff.Changed {
+			lines = append(lines, fmt.Sprintf("-%d %s", change.Before, change.Name), fmt.Sprintf("+%d %s", change.After, change.Name))
+		}
+		for _, change := range diff.Added {
+			lines = append(lines, fmt.Sprintf("+%d %s", change.After, change.Name))
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Returns the key cards are matched by in diffs (the oracle id, or the name for cards without one)
+func diffKey(card Card) string {
+	if card.OracleID != "" {
+		return card.OracleID
+	}
+	return "name:" + strings.ToLower(card.Name)
+}
+
+// Returns the amounts of the cards in the zone (diff key -- change with the amount in After)
+//
+// The copies of different printings and finishes of the card are added up
+func zoneAmounts(deck Deck, zone Zone) map[string]CardChange {
+	result := map[string]CardChange{}
+	for _, entry := range deck.getZone(zone).entries {
+		key := diffKey(entry.card)
+		change := result[key]
+		change.Name = entry.card.Name
+		change.OracleID = entry.card.OracleID
+		change.After += entry.amount
+		result[key] = change
+	}
+	return result
+}
+
+// Returns the changes between the two decks
+//
+// The cards are matched by their oracle ids in every zone, so replacing a card with another printing isn't a change
+func DiffDecks(a Deck, b Deck) DeckDiff {
+	result := DeckDiff{From: a.Name, To: b.Name, Zones: map[Zone]ZoneDiff{}}
+	for _, zone := range zoneOrder {
+		before := zoneAmounts(a, zone)
+		after := zoneAmounts(b, zone)
+		diff := ZoneDiff{}
+		for key, old := range before {
+			current, has := after[key]
+			switch {
+			case !has:
+				diff.Removed = append(diff.Removed, CardChange{Name: old.Name, OracleID: old.OracleID, Before: old.After})
+			case current.After != old.After:
+				current.Before = old.After
+				diff.Changed = append(diff.Changed, current)
+			}
+		}
+		for key, current := range after {
+			if _, has := before[key]; !has {
+				diff.Added = append(diff.Added, current)
+			}
+		}
+		if diff.Empty() {
+			continue
+		}
+		sortChanges(diff.Added)
+		sortChanges(diff.Removed)
+		sortChanges(diff.Changed)
+		result.Zones[zone] = diff
+	}
+	return result
+}
+
+// Sorts the changes by the card names
+func sortChanges(changes []CardChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		return strings.ToLower(changes[i].Name) < strings.ToLower(changes[j].Name)
+	})
+}
@@ -0,0 +1,57 @@
+package mtgsdk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffDecks(t *testing.T) {
+	bolt := Card{ID: "bolt-m10", OracleID: "bolt", Name: "Lightning Bolt", Set: "m10"}
+	boltReprint := Card{ID: "bolt-2x2", OracleID: "bolt", Name: "Lightning Bolt", Set: "2x2"}
+	duress := Card{ID: "duress-m19", OracleID: "duress", Name: "Duress"}
+	negate := Card{ID: "negate-m19", OracleID: "negate", Name: "Negate"}
+	forest := Card{ID: "forest-m21", OracleID: "forest", Name: "Forest"}
+	shock := Card{ID: "shock-m19", OracleID: "shock", Name: "Shock"}
+
+	old := CreateDeck("old")
+	old.AddCard(&bolt, 4)
+	old.AddCard(&forest, 10)
+	old.AddCard(&duress, 2)
+	old.AddCard(&shock, 2)
+	old.AddSideboardCard(&negate, 2)
+
+	current := CreateDeck("new")
+	// a different printing and finish of the same card isn't a change
+	current.AddCard(&boltReprint, 2)
+	current.AddCardWithFinish(&bolt, 2, FinishFoil)
+	current.AddCard(&forest, 12)
+	current.AddCard(&shock, 2)
+	current.AddSideboardCard(&duress, 2)
+	current.AddSideboardCard(&negate, 2)
+
+	diff := DiffDecks(*old, *current)
+	expected := DeckDiff{From: "old", To: "new", Zones: map[Zone]ZoneDiff{
+		ZoneMain: {
+			Removed: []CardChange{{Name: "Duress", OracleID: "duress", Before: 2}},
+			Changed: []CardChange{{Name: "Forest", OracleID: "forest", Before: 10, After: 12}},
+		},
+		// the moved card is removed from one zone and added to the other
+		ZoneSideboard: {
+			Added: []CardChange{{Name: "Duress", OracleID: "duress", After: 2}},
+		},
+	}}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("expected the diff\n%+v\ngot\n%+v", expected, diff)
+	}
+	if delta := diff.Zones[ZoneMain].Changed[0].Delta(); delta != 2 {
+		t.Fatalf("expected the delta 2, got %d", delta)
+	}
+	expectedText := "--- old\n+++ new\n@@ Deck @@\n-2 Duress\n-10 Forest\n+12 Forest\n@@ Sideboard @@\n+2 Duress\n"
+	if text := diff.String(); text != expectedText {
+		t.Fatalf("expected the diff text\n%s\ngot\n%s", expectedText, text)
+	}
+
+	if diff := DiffDecks(*old, *old); !diff.Empty() {
+		t.Fatalf("expected no changes between the same decks, got %+v", diff)
+	}
+}
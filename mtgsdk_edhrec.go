@@ -1,6 +1,7 @@
 package mtgsdk
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,15 +15,11 @@ import (
 )
 
 const (
-	commanderSearchURL = "https://edhrec.com/commanders/%s" // The url for edhrec.com
-	staplesURL         = "https://edhrec.com/top"           // The url for searching for staples
-
-	cardSelector = "div[class^=\"Card_container__\"]" // The selector for card elements
+	cardSelector   = "div[class^=\"Card_container__\"]" // The selector for card elements
+	maxNavAttempts = 5                                  // The maximum amount of attempts to load a page with cards
 )
 
 var (
-	browser *rod.Browser = nil // The browser that accesses the edhrec website
-
 	// Disallowed characters in the card name URL
 	dchars = []string{
 		",",
@@ -30,48 +27,100 @@ var (
 )
 
 // Turns the card name to a valid udhrec url
-func commanderURL(cardName string) string {
+func (c *Client) commanderURL(cardName string) string {
 	cname := strings.ToLower(cardName)
 	for _, dchar := range dchars {
 		cname = strings.ReplaceAll(cname, dchar, "")
 	}
 	cname = strings.ReplaceAll(cname, " ", "-")
-	return fmt.Sprintf(commanderSearchURL, cname)
+	return c.edhrecURL + fmt.Sprintf(commanderSearchPath, cname)
 }
 
-// Initializes the browser
-func initBrowser() error {
-	u := launcher.New().
+// Initializes the browser (if it's not initialized yet)
+//
+// The context only bounds the launch, the browser itself lives as long as the client
+func (c *Client) initBrowser(ctx context.Context) error {
+	c.browserMu.Lock()
+	defer c.browserMu.Unlock()
+	if c.browser != nil {
+		return nil
+	}
+	l := launcher.New().
+		Context(ctx).
 		// Headless(false).
-		Set("--blink-settings=imagesEnabled=false").
-		MustLaunch()
-
-	browser = rod.New().ControlURL(u)
-	err := browser.Connect()
+		Set("--blink-settings=imagesEnabled=false")
+	u, err := l.Launch()
 	if err != nil {
-		return err
+		return fmt.Errorf("mtgsdk - failed to launch the browser: %w", err)
+	}
+	if ctx.Err() != nil {
+		l.Kill()
+		return ctx.Err()
+	}
+	// the connection is bound to its context, so it can't use the one of the request
+	browser := rod.New().ControlURL(u)
+	err = browser.Connect()
+	if err != nil {
+		l.Kill()
+		return fmt.Errorf("mtgsdk - failed to connect to the browser: %w", err)
 	}
+	c.browser = browser
 	log.Println("Connected to browser")
 	return nil
 }
 
 // Navigates the browser to the specified url
-func nav(url string) (*rod.Page, error) {
+func (c *Client) nav(ctx context.Context, url string) (*rod.Page, error) {
+	c.browserMu.Lock()
+	browser := c.browser
+	c.browserMu.Unlock()
 	page, err := browser.Page(proto.TargetCreateTarget{})
 	if err != nil {
 		return nil, err
 	}
-	waitFunc := page.MustWaitNavigation()
+	page = page.Context(ctx)
+	waitFunc := page.WaitNavigation(proto.PageLifecycleEventNameNetworkAlmostIdle)
 	err = page.Navigate(url)
 	if err != nil {
+		page.Close()
 		return nil, err
 	}
 	log.Println("Connected to the page, rendering...")
 	waitFunc()
+	if ctx.Err() != nil {
+		page.Close()
+		return nil, ctx.Err()
+	}
 	log.Println("Page rendered!")
 	return page, nil
 }
 
+// Navigates to the url until the page has rendered the card elements
+//
+// Gives up after maxNavAttempts attempts or when the context is done
+func (c *Client) scrapeCardElements(ctx context.Context, url string) (*rod.Page, rod.Elements, error) {
+	for attempt := 0; attempt < maxNavAttempts; attempt++ {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		page, err := c.nav(ctx, url)
+		if err != nil {
+			return nil, nil, err
+		}
+		// scrape the elements
+		cardElems, err := page.Elements(cardSelector)
+		if err != nil {
+			page.Close()
+			return nil, nil, err
+		}
+		if len(cardElems) > 1 {
+			return page, cardElems, nil
+		}
+		page.Close()
+	}
+	return nil, nil, fmt.Errorf("mtgsdk - failed to find cards on %s after %d attempts", url, maxNavAttempts)
+}
+
 // Extracts the name and the synergy of the card from the text
 func extractNameAndSynergy(text string) (string, int, error) {
 	lines := strings.Split(text, "\n")
@@ -79,61 +128,59 @@ func extractNameAndSynergy(text string) (string, int, error) {
 	return lines[3], s, err
 }
 
-func toCardMap(data map[string]int) (map[*Card]int, error) {
+func (c *Client) toCardMap(ctx context.Context, data map[string]int) (map[*Card]int, error) {
+	identifiers := make([]CardIdentifier, 0, len(data))
+	for id := range data {
+		identifiers = append(identifiers, IdentifierByID(id))
+	}
+	cards, notFound, err := c.GetCardsByIdentifiersContext(ctx, identifiers, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(notFound) != 0 {
+		return nil, fmt.Errorf("mtgsdk - %d of the reccomended cards weren't found (first id: %s): %w", len(notFound), notFound[0].ID, ErrNotFound)
+	}
 	result := make(map[*Card]int, len(data))
-	for id, syn := range data {
-		card, err := GetCard(id)
-		if err != nil {
-			return nil, err
-		}
-		result[&card] = syn
+	for i := range cards {
+		result[&cards[i]] = data[cards[i].ID]
 	}
 	return result, nil
 }
 
 // Returns the map of cards id to synergy
-func reccomendCards(cardID string, offline bool) (map[*Card]int, error) {
+func (c *Client) reccomendCards(ctx context.Context, cardID string, offline bool) (map[*Card]int, error) {
 	log.Printf("Searching the best cards for %s", cardID)
 	var err error
 	// check if the data exists locally
-	data, has := edhrecData[cardID]
+	c.edhrecMu.RLock()
+	data, has := c.edhrecData[cardID]
+	c.edhrecMu.RUnlock()
 	if has {
-		return toCardMap(data)
+		return c.toCardMap(ctx, data)
 	}
 	if !has && offline {
 		return nil, fmt.Errorf("mtgsdk - can't reccomend cards for %s: no local data", cardID)
 	}
 	// data doesn't exist locally, fetching for it online
 	// init the browser
-	if browser == nil {
-		err = initBrowser()
-		if err != nil {
-			return nil, err
-		}
+	err = c.initBrowser(ctx)
+	if err != nil {
+		return nil, err
 	}
-	card, err := GetCard(cardID)
+	card, err := c.GetCardContext(ctx, cardID)
 	if err != nil {
 		return nil, err
 	}
-	url := commanderURL(card.Name)
+	url := c.commanderURL(card.Name)
 	log.Printf("Accessing %s...", url)
 
-	var cardElems rod.Elements
-	var page *rod.Page
 	// access the page
-	for {
-		page, err = nav(url)
-		if err != nil {
-			return nil, err
-		}
-		// scrape the elements
-		cardElems = page.MustElements(cardSelector)
-		if len(cardElems) > 1 {
-			break
-		}
+	page, cardElems, err := c.scrapeCardElements(ctx, url)
+	if err != nil {
+		return nil, err
 	}
 	cardElems = cardElems[1:] // Skip the first element - it's the commander itself
-	defer page.MustClose()
+	defer page.Close()
 	amount := len(cardElems)
 	log.Printf("Found %d cards", amount)
 	result := make(map[string]int, amount)
@@ -147,55 +194,50 @@ func reccomendCards(cardID string, offline bool) (map[*Card]int, error) {
 		if err != nil {
 			return nil, err
 		}
-		cards, err := GetCards(map[string]string{CardNameKey: name}, offline)
+		reccCard, err := c.resolveName(ctx, name, NameExact, offline)
 		if err != nil {
 			return nil, err
 		}
-		card := cards[0]
-		result[card.ID] = synergy
+		result[reccCard.ID] = synergy
 	}
 	log.Printf("Card stats for %s loaded!", card.Name)
 	// save locally
-	edhrecData[cardID] = result
-	err = saveEDHRECData()
+	c.edhrecMu.Lock()
+	c.edhrecData[cardID] = result
+	c.edhrecMu.Unlock()
+	err = c.saveEDHRECData()
 	if err != nil {
 		return nil, err
 	}
-	return toCardMap(result)
+	return c.toCardMap(ctx, result)
 }
 
 // Returns a slice of all commander staple cards (according to edhrec.com)
-func GetEDHRECStaples(offline bool) ([]Card, error) {
+func (c *Client) GetEDHRECStaples(offline bool) ([]Card, error) {
+	return c.GetEDHRECStaplesContext(context.Background(), offline)
+}
+
+// Same as GetEDHRECStaples, but uses the context for the network requests
+func (c *Client) GetEDHRECStaplesContext(ctx context.Context, offline bool) ([]Card, error) {
 	if offline {
-		return getLocalEDHRECStaples()
+		return c.getLocalEDHRECStaples(ctx)
 	}
 	var err error
-	if browser == nil {
-		err = initBrowser()
-		if err != nil {
-			return nil, err
-		}
+	err = c.initBrowser(ctx)
+	if err != nil {
+		return nil, err
 	}
-	log.Printf("Accessing %s", staplesURL)
-	var cardElems rod.Elements
-	var page *rod.Page
+	log.Printf("Accessing %s", c.edhrecURL+staplesPath)
 	// access the page
-	for {
-		page, err = nav(staplesURL)
-		if err != nil {
-			navErr := error(&rod.ErrNavigation{})
-			if errors.As(err, &navErr) {
-				return getLocalEDHRECStaples()
-			}
-			return nil, err
-		}
-		// scrape the elements
-		cardElems = page.MustElements(cardSelector)
-		if len(cardElems) > 1 {
-			break
+	page, cardElems, err := c.scrapeCardElements(ctx, c.edhrecURL+staplesPath)
+	if err != nil {
+		var navErr *rod.ErrNavigation
+		if errors.As(err, &navErr) {
+			return c.getLocalEDHRECStaples(ctx)
 		}
+		return nil, err
 	}
-	defer page.MustClose()
+	defer page.Close()
 	amount := len(cardElems)
 	log.Printf("Found %d cards", amount)
 	result := []Card{}
@@ -209,22 +251,22 @@ func GetEDHRECStaples(offline bool) ([]Card, error) {
 			continue
 		}
 		cardName := lines[3]
-		cards, err := GetCards(map[string]string{CardNameKey: cardName}, offline)
+		staple, err := c.resolveName(ctx, cardName, NameExact, offline)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
-		if len(cards) == 0 {
-			continue
-		}
-		result = append(result, cards[0])
+		result = append(result, staple)
 	}
-	err = saveEDHRECStaples(result)
+	err = c.saveEDHRECStaples(result)
 	return result, err
 }
 
 // Reads the local edhrec staple cards
-func getLocalEDHRECStaples() ([]Card, error) {
-	data, err := adm.ReadFile(edhrecStaplesFile)
+func (c *Client) getLocalEDHRECStaples(ctx context.Context) ([]Card, error) {
+	data, err := c.adm.ReadFile(edhrecStaplesFile)
 	if err != nil {
 		return nil, err
 	}
@@ -232,7 +274,7 @@ func getLocalEDHRECStaples() ([]Card, error) {
 	err = json.Unmarshal(data, &ids)
 	result := make([]Card, len(ids))
 	for i, id := range ids {
-		result[i], err = GetCard(id)
+		result[i], err = c.GetCardContext(ctx, id)
 		if err != nil {
 			return nil, err
 		}
@@ -241,7 +283,7 @@ func getLocalEDHRECStaples() ([]Card, error) {
 }
 
 // Saves edhrec staples to the edhrec staples file
-func saveEDHRECStaples(cards []Card) error {
+func (c *Client) saveEDHRECStaples(cards []Card) error {
 	ids := make([]string, len(cards))
 	for i, card := range cards {
 		ids[i] = card.ID
@@ -250,6 +292,8 @@ func saveEDHRECStaples(cards []Card) error {
 	if err != nil {
 		return err
 	}
-	err = adm.WriteToFile(edhrecStaplesFile, data)
+	c.edhrecMu.Lock()
+	defer c.edhrecMu.Unlock()
+	err = c.adm.WriteToFile(edhrecStaplesFile, data)
 	return err
 }
@@ -0,0 +1,134 @@
+package mtgsdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotFound    = errors.New("mtgsdk - not found")         // The requested cards don't exist
+	ErrBadRequest  = errors.New("mtgsdk - bad request")       // The request or query was invalid
+	ErrRateLimited = errors.New("mtgsdk - rate limited")      // Too many requests were sent
+	ErrServer      = errors.New("mtgsdk - server error")      // The server failed to process the request
+	ErrAmbiguous   = errors.New("mtgsdk - ambiguous request") // The request matched too many cards
+)
+
+// An error object returned by the scryfall api
+//
+// Matches ErrNotFound, ErrBadRequest, ErrRateLimited, ErrServer and ErrAmbiguous with errors.Is
+type ScryfallError struct {
+	Status   int      `json:"status"`   // The http status code
+	Code     string   `json:"code"`     // The scryfall error code
+	Details  string   `json:"details"`  // The human-readable description of the error
+	Type     string   `json:"type"`     // The additional type of the error (for example, ambiguous)
+	Warnings []string `json:"warnings"` // The additional problems with the request
+	URL      string   `json:"-"`        // The url of the request
+}
+
+func (e *ScryfallError) Error() string {
+	return fmt.Sprintf("mtgsdk - request to %s failed with status %d (%s): %s", e.URL, e.Status, e.Code, e.Details)
+}
+
+// Matches the error against the sentinel errors
+func (e *ScryfallError) Is(target error) bool {
+	switch target {
+	case ErrAmbiguous:
+		return e.Type == "ambiguous"
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrBadRequest:
+		return e.Status == http.StatusBadRequest || e.Status == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrServer:
+		return e.Status >= 500
+	}
+	return false
+}
+
+// Returns a ScryfallError if the response doesn't have a 2xx status
+//
+// Closes the response body if returns an error
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	defer resp.Body.Close()
+	result := &ScryfallError{}
+	data, err := io.ReadAll(resp.Body)
+	if err == nil {
+		// not every host returns a scryfall error object, so the body is optional
+		json.Unmarshal(data, result)
+	}
+	result.Status = resp.StatusCode
+	result.URL = resp.Request.URL.String()
+	if result.Code == "" {
+		result.Code = http.StatusText(resp.StatusCode)
+	}
+	if result.Details == "" {
+		result.Details = http.StatusText(resp.StatusCode)
+	}
+	return result
+}
+
+// Multiple errors from a bulk operation
+//
+// Each error can be matched with errors.Is and errors.As
+type MultiError struct {
+	Errors []error // The errors
+}
+
+func (e *MultiError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Returns the errors (used by errors.Is and errors.As)
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
+
+// Matches the target against every error
+func (e *MultiError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Joins the non-nil errors into a MultiError
+//
+// Returns nil if there are no errors and the error itself if there is only one
+func joinErrors(errs ...error) error {
+	result := &MultiError{}
+	for _, err := range errs {
+		if err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+	}
+	switch len(result.Errors) {
+	case 0:
+		return nil
+	case 1:
+		return result.Errors[0]
+	}
+	return result
+}
+
+// Reads all the errors from the closed channel
+func collectErrors(errs <-chan error) []error {
+	result := []error{}
+	for err := range errs {
+		result = append(result, err)
+	}
+	return result
+}
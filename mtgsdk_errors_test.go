@@ -0,0 +1,75 @@
+package mtgsdk
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestScryfallErrorSentinels(t *testing.T) {
+	sentinels := []error{ErrNotFound, ErrBadRequest, ErrRateLimited, ErrServer, ErrAmbiguous}
+	for _, test := range []struct {
+		status   int    // The status of the response
+		body     string // The body of the response
+		expected error  // The sentinel the error has to match (the others must not match)
+	}{
+		{http.StatusNotFound, `{"object": "error", "code": "not_found", "status": 404, "details": "No cards found"}`, ErrNotFound},
+		{http.StatusBadRequest, `{"object": "error", "code": "bad_request", "status": 400, "details": "Bad query"}`, ErrBadRequest},
+		{http.StatusUnprocessableEntity, `{"object": "error", "code": "bad_request", "status": 422, "details": "All of your terms were ignored", "warnings": ["unknown field"]}`, ErrBadRequest},
+		{http.StatusTooManyRequests, ``, ErrRateLimited},
+		{http.StatusInternalServerError, `not json`, ErrServer},
+		{http.StatusServiceUnavailable, ``, ErrServer},
+	} {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		})
+		_, err := client.FetchQuery(Field(nameQueryKey, Has, "card"))
+		var scryfallErr *ScryfallError
+		if !errors.As(err, &scryfallErr) {
+			t.Fatalf("%d: expected a ScryfallError, got %v", test.status, err)
+		}
+		if scryfallErr.Status != test.status || scryfallErr.Details == "" {
+			t.Fatalf("%d: unexpected error %+v", test.status, scryfallErr)
+		}
+		for _, sentinel := range sentinels {
+			if matches := errors.Is(err, sentinel); matches != (sentinel == test.expected) {
+				t.Fatalf("%d: expected to match only %v, but matching %v is %v", test.status, test.expected, sentinel, matches)
+			}
+		}
+	}
+}
+
+func TestScryfallErrorAmbiguous(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"object": "error", "code": "not_found", "status": 404, "type": "ambiguous", "details": "Too many cards match"}`))
+	})
+	_, err := client.ResolveName("bolt", NameFuzzy)
+	if !errors.Is(err, ErrAmbiguous) || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected an ambiguous not found error, got %v", err)
+	}
+}
+
+func TestDownloadImageError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	card := Card{ID: "missing", ImageUris: ImageURIs{Normal: client.apiURL + "/images/missing"}}
+	outPath := t.TempDir()
+	err := client.DownloadImage(card, outPath, ImageQualityNormal)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	// nothing is cached or saved, so the next download tries again
+	fileName := "missing_normal." + imageFileFormat
+	exists, err := client.adm.FileExists(path.Join(imagesFolder, fileName))
+	if err != nil || exists {
+		t.Fatalf("expected no cached image, got %v %v", exists, err)
+	}
+	if _, err := os.Stat(path.Join(outPath, fileName)); !os.IsNotExist(err) {
+		t.Fatalf("expected no saved image, got %v", err)
+	}
+}
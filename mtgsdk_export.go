@@ -0,0 +1,354 @@
+package mtgsdk
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Writes the deck to the writer in a file format
+type DeckWriter func(w io.Writer, deck Deck) error
+
+var (
+	// The writers of the file formats (format -- writer)
+	deckWriters = map[FileFormat]DeckWriter{
+		FileFormatText:       writeText,
+		FileFormatArena:      writeArena,
+		FileFormatMTGO:       writeMTGO,
+		FileFormatCockatrice: writeCockatrice,
+		FileFormatForge:      writeForge,
+		FileFormatTappedOut:  writeTappedOut,
+		FileFormatMoxfield:   writeMoxfield,
+		FileFormatCSV:        writeCSV,
+	}
+
+	// Guards the deck writers
+	deckWritersMu sync.RWMutex
+
+	// The headers of the zones in arena exports (zones without a header aren't exported)
+	arenaHeaders = map[Zone]string{
+		ZoneCommander: "Commander",
+		ZoneCompanion: "Companion",
+		ZoneMain:      "Deck",
+		ZoneSideboard: "Sideboard",
+	}
+
+	// The sections of the zones in forge files (zones without a section aren't exported)
+	forgeZoneSections = map[Zone]string{
+		ZoneCommander: "Commander",
+		ZoneMain:      "Main",
+		ZoneCompanion: "Sideboard",
+		ZoneSideboard: "Sideboard",
+	}
+)
+
+// Registers the writer of the file format, replacing the existing one
+//
+// Can be used to add custom formats or to override the built-in writers (Deck.Save always uses the built-in text writer)
+func RegisterDeckWriter(format FileFormat, writer DeckWriter) {
+	deckWritersMu.Lock()
+	defer deckWritersMu.Unlock()
+	deckWriters[format] = writer
+}
+
+// Writes the deck to the writer in the file format
+func (d Deck) Export(w io.Writer, format FileFormat) error {
+	deckWritersMu.RLock()
+	writer, has := deckWriters[format]
+	deckWritersMu.RUnlock()
+	if !has {
+		return fmt.Errorf("mtgsdk - no writer for deck file format %s", format)
+	}
+	return writer(w, d)
+}
+
+// Writes the lines to the writer, separated by new lines
+func writeLines(w io.Writer, lines []string) error {
+	_, err := io.WriteString(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// Writes the deck as a plain decklist (see Deck.Save)
+func writeText(w io.Writer, deck Deck) error {
+	lines := []string{}
+	zones := deck.usedZones()
+	for _, zone := range zones {
+		if len(lines) != 0 {
+			lines = append(lines, "")
+		}
+		if zone != ZoneMain || len(zones) > 1 {
+			lines = append(lines, string(zone))
+		}
+		for _, entry := range deck.ZoneEntries(zone) {
+			lines = append(lines, entry.String())
+		}
+	}
+	return writeLines(w, lines)
+}
+
+// Merges the entries of the different finishes of a card into one nonfoil entry (for the formats without finishes)
+func withoutFinishes(entries []DeckEntry) []DeckEntry {
+	result := []DeckEntry{}
+	indices := map[string]int{}
+	for _, entry := range entries {
+		if i, has := indices[entry.Card.ID]; has {
+			result[i].Amount += entry.Amount
+			continue
+		}
+		indices[entry.Card.ID] = len(result)
+		entry.Finish = FinishNonfoil
+		result = append(result, entry)
+	}
+	return result
+}
+
+// Writes the deck as an arena export (4 Lightning Bolt (M10) 146)
+//
+// Arena doesn't have finishes or a maybeboard, so they aren't exported
+func writeArena(w io.Writer, deck Deck) error {
+	lines := []string{}
+	if deck.Name != "" {
+		lines = append(lines, "About", "Name "+deck.Name, "")
+	}
+	for _, zone := range zoneOrder {
+		header, has := arenaHeaders[zone]
+		entries := withoutFinishes(deck.ZoneEntries(zone))
+		if !has || len(entries) == 0 {
+			continue
+		}
+		if len(lines) != 0 && lines[len(lines)-1] != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, header)
+		for _, entry := range entries {
+			lines = append(lines, entry.String())
+		}
+	}
+	return writeLines(w, lines)
+}
+
+// Writes the deck as a mtgo .dek file
+//
+// The commanders and the companion are put in the sideboard, the maybeboard isn't exported
+func writeMTGO(w io.Writer, deck Deck) error {
+	type mtgoCard struct {
+		CatID     int    `xml:"CatID,attr"`
+		Quantity  int    `xml:"Quantity,attr"`
+		Sideboard bool   `xml:"Sideboard,attr"`
+		Name      string `xml:"Name,attr"`
+	}
+	type mtgoDeck struct {
+		XMLName              xml.Name   `xml:"Deck"`
+		NetDeckID            int        `xml:"NetDeckID"`
+		PreconstructedDeckID int        `xml:"PreconstructedDeckID"`
+		Cards                []mtgoCard `xml:"Cards"`
+	}
+	result := mtgoDeck{}
+	for _, zone := range []Zone{ZoneMain, ZoneCommander, ZoneCompanion, ZoneSideboard} {
+		for _, entry := range withoutFinishes(deck.ZoneEntries(zone)) {
+			result.Cards = append(result.Cards, mtgoCard{
+				CatID:     entry.Card.MtgoID,
+				Quantity:  entry.Amount,
+				Sideboard: zone != ZoneMain,
+				Name:      entry.Card.Name,
+			})
+		}
+	}
+	return writeXML(w, result)
+}
+
+// Writes the deck as a cockatrice .cod file
+//
+// The commanders are put in the main zone and the companion in the side zone, the maybeboard isn't exported
+func writeCockatrice(w io.Writer, deck Deck) error {
+	type cockatriceCard struct {
+		Number          int    `xml:"number,attr"`
+		Name            string `xml:"name,attr"`
+		SetCode         string `xml:"setShortName,attr,omitempty"`
+		CollectorNumber string `xml:"collectorNumber,attr,omitempty"`
+	}
+	type cockatriceZone struct {
+		Name  string           `xml:"name,attr"`
+		Cards []cockatriceCard `xml:"card"`
+	}
+	type cockatriceDeck struct {
+		XMLName  xml.Name         `xml:"cockatrice_deck"`
+		Version  int              `xml:"version,attr"`
+		DeckName string           `xml:"deckname"`
+		Comments string           `xml:"comments"`
+		Zones    []cockatriceZone `xml:"zone"`
+	}
+	result := cockatriceDeck{Version: 1, DeckName: deck.Name, Comments: deck.Notes}
+	for _, zone := range []cockatriceZone{{Name: "main"}, {Name: "side"}} {
+		zones := []Zone{ZoneCommander, ZoneMain}
+		if zone.Name == "side" {
+			zones = []Zone{ZoneCompanion, ZoneSideboard}
+		}
+		for _, z := range zones {
+			for _, entry := range withoutFinishes(deck.ZoneEntries(z)) {
+				zone.Cards = append(zone.Cards, cockatriceCard{
+					Number:          entry.Amount,
+					Name:            entry.Card.Name,
+					SetCode:         strings.ToUpper(entry.Card.Set),
+					CollectorNumber: entry.Card.CollectorNumber,
+				})
+			}
+		}
+		if len(zone.Cards) != 0 {
+			result.Zones = append(result.Zones, zone)
+		}
+	}
+	return writeXML(w, result)
+}
+
+// Writes the value as an indented xml document
+func writeXML(w io.Writer, v interface{}) error {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, xml.Header+string(data)+"\n")
+	return err
+}
+
+// Writes the deck as a forge .dck file (4 Lightning Bolt|M10)
+//
+// The companion is put in the sideboard, the maybeboard isn't exported
+func writeForge(w io.Writer, deck Deck) error {
+	lines := []string{"[metadata]", "Name=" + deck.Name}
+	if deck.Notes != "" {
+		lines = append(lines, "Description="+strings.ReplaceAll(deck.Notes, "\n", " "))
+	}
+	for _, section := range []string{"Commander", "Main", "Sideboard"} {
+		sectionLines := []string{}
+		for _, zone := range zoneOrder {
+			if forgeZoneSections[zone] != section {
+				continue
+			}
+			for _, entry := range withoutFinishes(deck.ZoneEntries(zone)) {
+				line := fmt.Sprintf("%d %s", entry.Amount, entry.Card.Name)
+				if entry.Card.Set != "" {
+					line += "|" + strings.ToUpper(entry.Card.Set)
+				}
+				sectionLines = append(sectionLines, line)
+			}
+		}
+		if len(sectionLines) != 0 {
+			lines = append(lines, "["+section+"]")
+			lines = append(lines, sectionLines...)
+		}
+	}
+	return writeLines(w, append(lines, ""))
+}
+
+// Writes the deck as a tappedout list (4x Lightning Bolt (M10) *F*), which moxfield can import as well
+//
+// The commanders are marked with *CMDR*, the companion is put in the sideboard
+func writeTappedOut(w io.Writer, deck Deck) error {
+	// returns the line of the entry
+	entryLine := func(entry DeckEntry) string {
+		result := fmt.Sprintf("%dx %s", entry.Amount, entry.Card.Name)
+		if entry.Card.Set != "" {
+			result += fmt.Sprintf(" (%s)", strings.ToUpper(entry.Card.Set))
+		}
+		if marker, has := finishMarkers[entry.Finish]; has {
+			result += " " + marker
+		}
+		return result
+	}
+	lines := []string{}
+	for _, entry := range deck.ZoneEntries(ZoneCommander) {
+		lines = append(lines, entryLine(entry)+" "+commanderLineSuffix)
+	}
+	for _, entry := range deck.ZoneEntries(ZoneMain) {
+		lines = append(lines, entryLine(entry))
+	}
+	sections := []struct {
+		header string
+		zones  []Zone
+	}{
+		{"Sideboard:", []Zone{ZoneCompanion, ZoneSideboard}},
+		{"Maybeboard:", []Zone{ZoneMaybeboard}},
+	}
+	for _, section := range sections {
+		sectionLines := []string{}
+		for _, zone := range section.zones {
+			for _, entry := range deck.ZoneEntries(zone) {
+				sectionLines = append(sectionLines, entryLine(entry))
+			}
+		}
+		if len(sectionLines) != 0 {
+			lines = append(lines, "", section.header)
+			lines = append(lines, sectionLines...)
+		}
+	}
+	return writeLines(w, lines)
+}
+
+// Writes the deck as a moxfield csv file, which keeps the printings and the finishes (read back by the moxfield importer)
+func writeMoxfield(w io.Writer, deck Deck) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"Count", "Name", "Edition", "Collector Number", "Foil", "Board"})
+	if err != nil {
+		return err
+	}
+	for _, zone := range deck.usedZones() {
+		for _, entry := range deck.ZoneEntries(zone) {
+			foil := ""
+			if entry.Finish != FinishNonfoil {
+				foil = string(entry.Finish)
+			}
+			err = writer.Write([]string{
+				strconv.Itoa(entry.Amount),
+				entry.Card.Name,
+				entry.Card.Set,
+				entry.Card.CollectorNumber,
+				foil,
+				strings.ToLower(string(zone)),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Writes the deck as a csv file with the printings, the finishes and the usd prices of single copies
+//
+// The price is empty if scryfall doesn't have it
+func writeCSV(w io.Writer, deck Deck) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"Count", "Name", "Set", "Collector Number", "Finish", "Board", "Price (USD)", "Scryfall ID"})
+	if err != nil {
+		return err
+	}
+	for _, zone := range deck.usedZones() {
+		for _, entry := range deck.ZoneEntries(zone) {
+			price := ""
+			if amount, has := entry.Card.Price(CurrencyUSD, entry.Finish); has {
+				price = strconv.FormatFloat(amount, 'f', 2, 64)
+			}
+			err = writer.Write([]string{
+				strconv.Itoa(entry.Amount),
+				entry.Card.Name,
+				entry.Card.Set,
+				entry.Card.CollectorNumber,
+				string(entry.Finish),
+				strings.ToLower(string(zone)),
+				price,
+				entry.Card.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
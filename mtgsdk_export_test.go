@@ -0,0 +1,168 @@
+package mtgsdk
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+var (
+	// The cards of the export test deck
+	exportTestCards = []Card{
+		{ID: "atraxa", Name: "Atraxa, Praetors' Voice", Set: "2x2", CollectorNumber: "190", MtgoID: 101, TypeLine: "Legendary Creature — Phyrexian Angel Horror"},
+		{ID: "sol-ring", Name: "Sol Ring", Set: "c21", CollectorNumber: "263", MtgoID: 102, TypeLine: "Artifact", Prices: Prices{USD: "1.50", USDFoil: "4.00"}},
+		{ID: "forest", Name: "Forest", Set: "m21", CollectorNumber: "274", MtgoID: 103, TypeLine: "Basic Land — Forest"},
+		{ID: "duress", Name: "Duress", Set: "m19", CollectorNumber: "94", MtgoID: 104, TypeLine: "Sorcery"},
+		{ID: "bolt", Name: "Lightning Bolt", Set: "m10", CollectorNumber: "146", MtgoID: 105, TypeLine: "Instant"},
+	}
+)
+
+// Returns the deck used by the export tests (a commander, a card in two finishes, a sideboard and a maybeboard)
+func exportTestDeck() Deck {
+	deck := CreateDeck("Export Test")
+	deck.SetCommanders(exportTestCards[0])
+	deck.AddCardWithFinish(&exportTestCards[1], 1, FinishNonfoil)
+	deck.AddCardWithFinish(&exportTestCards[1], 1, FinishFoil)
+	deck.AddCard(&exportTestCards[2], 10)
+	deck.AddSideboardCard(&exportTestCards[3], 1)
+	deck.AddToZone(ZoneMaybeboard, &exportTestCards[4], 1)
+	return *deck
+}
+
+// Returns the entries of every zone of the deck (zone amount id finish)
+func deckSummary(deck Deck) []string {
+	result := []string{}
+	for _, zone := range zoneOrder {
+		for _, entry := range deck.ZoneEntries(zone) {
+			result = append(result, fmt.Sprintf("%s %d %s %s", zone, entry.Amount, entry.Card.ID, entry.Finish))
+		}
+	}
+	return result
+}
+
+func TestDeckWriters(t *testing.T) {
+	deck := exportTestDeck()
+	for format, expected := range map[FileFormat]string{
+		FileFormatText: "Commander\n1 Atraxa, Praetors' Voice (2X2) 190\n\nDeck\n1 Sol Ring (C21) 263\n1 Sol Ring (C21) 263 *F*\n10 Forest (M21) 274\n\n" +
+			"Sideboard\n1 Duress (M19) 94\n\nMaybeboard\n1 Lightning Bolt (M10) 146",
+		FileFormatArena: "About\nName Export Test\n\nCommander\n1 Atraxa, Praetors' Voice (2X2) 190\n\nDeck\n2 Sol Ring (C21) 263\n10 Forest (M21) 274\n\n" +
+			"Sideboard\n1 Duress (M19) 94",
+		FileFormatMTGO: xml.Header + "<Deck>\n  <NetDeckID>0</NetDeckID>\n  <PreconstructedDeckID>0</PreconstructedDeckID>\n" +
+			"  <Cards CatID=\"102\" Quantity=\"2\" Sideboard=\"false\" Name=\"Sol Ring\"></Cards>\n" +
+			"  <Cards CatID=\"103\" Quantity=\"10\" Sideboard=\"false\" Name=\"Forest\"></Cards>\n" +
+			"  <Cards CatID=\"101\" Quantity=\"1\" Sideboard=\"true\" Name=\"Atraxa, Praetors&#39; Voice\"></Cards>\n" +
+			"  <Cards CatID=\"104\" Quantity=\"1\" Sideboard=\"true\" Name=\"Duress\"></Cards>\n</Deck>\n",
+		FileFormatCockatrice: xml.Header + "<cockatrice_deck version=\"1\">\n  <deckname>Export Test</deckname>\n  <comments></comments>\n  <zone name=\"main\">\n" +
+			"    <card number=\"1\" name=\"Atraxa, Praetors&#39; Voice\" setShortName=\"2X2\" collectorNumber=\"190\"></card>\n" +
+			"    <card number=\"2\" name=\"Sol Ring\" setShortName=\"C21\" collectorNumber=\"263\"></card>\n" +
+			"    <card number=\"10\" name=\"Forest\" setShortName=\"M21\" collectorNumber=\"274\"></card>\n  </zone>\n  <zone name=\"side\">\n" +
+			"    <card number=\"1\" name=\"Duress\" setShortName=\"M19\" collectorNumber=\"94\"></card>\n  </zone>\n</cockatrice_deck>\n",
+		FileFormatForge: "[metadata]\nName=Export Test\n[Commander]\n1 Atraxa, Praetors' Voice|2X2\n[Main]\n2 Sol Ring|C21\n10 Forest|M21\n[Sideboard]\n1 Duress|M19\n",
+		FileFormatTappedOut: "1x Atraxa, Praetors' Voice (2X2) *CMDR*\n1x Sol Ring (C21)\n1x Sol Ring (C21) *F*\n10x Forest (M21)\n\n" +
+			"Sideboard:\n1x Duress (M19)\n\nMaybeboard:\n1x Lightning Bolt (M10)",
+		FileFormatMoxfield: "Count,Name,Edition,Collector Number,Foil,Board\n1,\"Atraxa, Praetors' Voice\",2x2,190,,commander\n1,Sol Ring,c21,263,,deck\n" +
+			"1,Sol Ring,c21,263,foil,deck\n10,Forest,m21,274,,deck\n1,Duress,m19,94,,sideboard\n1,Lightning Bolt,m10,146,,maybeboard\n",
+		FileFormatCSV: "Count,Name,Set,Collector Number,Finish,Board,Price (USD),Scryfall ID\n1,\"Atraxa, Praetors' Voice\",2x2,190,nonfoil,commander,,atraxa\n" +
+			"1,Sol Ring,c21,263,nonfoil,deck,1.50,sol-ring\n1,Sol Ring,c21,263,foil,deck,4.00,sol-ring\n10,Forest,m21,274,nonfoil,deck,,forest\n" +
+			"1,Duress,m19,94,nonfoil,sideboard,,duress\n1,Lightning Bolt,m10,146,nonfoil,maybeboard,,bolt\n",
+	} {
+		var buffer bytes.Buffer
+		err := deck.Export(&buffer, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if buffer.String() != expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", format, expected, buffer.String())
+		}
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	client := newTestClient(t, serveCardList(exportTestCards))
+	deck := exportTestDeck()
+	full := deckSummary(deck)
+	// the formats without finishes merge the copies of sol ring
+	merged := []string{"Commander 1 atraxa nonfoil", "Deck 2 sol-ring nonfoil", "Deck 10 forest nonfoil", "Sideboard 1 duress nonfoil"}
+	for format, expected := range map[FileFormat][]string{
+		FileFormatText:       full,
+		FileFormatTappedOut:  full,
+		FileFormatMoxfield:   full,
+		FileFormatCSV:        full,
+		FileFormatArena:      merged,
+		FileFormatForge:      merged,
+		FileFormatCockatrice: {"Deck 1 atraxa nonfoil", "Deck 2 sol-ring nonfoil", "Deck 10 forest nonfoil", "Sideboard 1 duress nonfoil"},
+		FileFormatMTGO:       {"Deck 2 sol-ring nonfoil", "Deck 10 forest nonfoil", "Sideboard 1 atraxa nonfoil", "Sideboard 1 duress nonfoil"},
+	} {
+		var buffer bytes.Buffer
+		err := deck.Export(&buffer, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		imported, err := client.ImportDeck(&buffer, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if got := deckSummary(imported); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: expected the entries %v, got %v", format, expected, got)
+		}
+	}
+}
+
+func TestRegisterDeckWriter(t *testing.T) {
+	const custom FileFormat = "custom"
+	deckWritersMu.RLock()
+	builtinText := deckWriters[FileFormatText]
+	deckWritersMu.RUnlock()
+	t.Cleanup(func() {
+		RegisterDeckWriter(FileFormatText, builtinText)
+		deckWritersMu.Lock()
+		delete(deckWriters, custom)
+		deckWritersMu.Unlock()
+	})
+	RegisterDeckWriter(custom, func(w io.Writer, deck Deck) error {
+		_, err := fmt.Fprintf(w, "%s: %d cards", deck.Name, deck.TotalCount())
+		return err
+	})
+	RegisterDeckWriter(FileFormatText, func(w io.Writer, deck Deck) error {
+		_, err := io.WriteString(w, "overridden")
+		return err
+	})
+
+	deck := exportTestDeck()
+	var buffer bytes.Buffer
+	err := deck.Export(&buffer, custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buffer.String() != "Export Test: 13 cards" {
+		t.Fatalf("unexpected custom export %q", buffer.String())
+	}
+	err = deck.Export(&buffer, "unknown")
+	if err == nil {
+		t.Fatal("expected an error for a format without a writer")
+	}
+
+	// the overridden text writer doesn't change the saved files
+	file := path.Join(t.TempDir(), "deck.txt")
+	err = deck.Save(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected bytes.Buffer
+	err = writeText(&expected, deck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(saved) != expected.String() {
+		t.Fatalf("expected the built-in text format, got %q", saved)
+	}
+}
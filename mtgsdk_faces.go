@@ -0,0 +1,177 @@
+package mtgsdk
+
+import "strings"
+
+// Card layouts (used in scryfall api)
+const (
+	LayoutNormal           = "normal"
+	LayoutSplit            = "split"
+	LayoutFlip             = "flip"
+	LayoutTransform        = "transform"
+	LayoutModalDFC         = "modal_dfc"
+	LayoutMeld             = "meld"
+	LayoutLeveler          = "leveler"
+	LayoutAdventure        = "adventure"
+	LayoutSaga             = "saga"
+	LayoutDoubleFacedToken = "double_faced_token"
+	LayoutReversibleCard   = "reversible_card"
+
+	faceSeparator = " // " // the separator of face names, costs and type lines
+)
+
+// URLs for the card images
+type ImageURIs struct {
+	Small      string `json:"small"`
+	Normal     string `json:"normal"`
+	Large      string `json:"large"`
+	PNG        string `json:"png"`
+	ArtCrop    string `json:"art_crop"`
+	BorderCrop string `json:"border_crop"`
+}
+
+// Returns the url of the image with the quality
+func (u ImageURIs) get(quality ImageQuality) string {
+	switch quality {
+	case ImageQualitySmall:
+		return u.Small
+	case ImageQualityNormal:
+		return u.Normal
+	case ImageQualityLarge:
+		return u.Large
+	}
+	return ""
+}
+
+// A face of a multi-faced card (transform, modal double-faced, split, adventure and flip cards)
+type CardFace struct {
+	Name           string    `json:"name"`            // The name of the face
+	ManaCost       string    `json:"mana_cost"`       // The raw manacost of the face
+	Cmc            float64   `json:"cmc"`             // The mana value of the face (only set for reversible cards)
+	TypeLine       string    `json:"type_line"`       // The type line of the face
+	OracleText     string    `json:"oracle_text"`     // The oracle text of the face
+	Colors         []string  `json:"colors"`          // The colors of the face
+	Power          string    `json:"power"`           // The power of the face
+	Toughness      string    `json:"toughness"`       // The toughness of the face
+	Loyalty        string    `json:"loyalty"`         // The loyalty of the face
+	FlavorText     string    `json:"flavor_text"`     // The flavor text of the face
+	ImageUris      ImageURIs `json:"image_uris"`      // The images of the face (only set if the faces are on different sides)
+	IllustrationID string    `json:"illustration_id"` // ID of the illustration
+}
+
+// Returns the faces of the card
+//
+// Cards with a single face return a face built from the card itself
+func (c Card) Faces() []CardFace {
+	if len(c.CardFaces) != 0 {
+		return c.CardFaces
+	}
+	return []CardFace{{
+		Name:           c.Name,
+		ManaCost:       c.ManaCost,
+		Cmc:            c.Cmc,
+		TypeLine:       c.TypeLine,
+		OracleText:     c.OracleText,
+		Colors:         c.Colors,
+		Power:          c.Power,
+		Toughness:      c.Toughness,
+		Loyalty:        c.Loyalty,
+		ImageUris:      c.ImageUris,
+		IllustrationID: c.IllustrationID,
+	}}
+}
+
+// Returns the front face of the card
+func (c Card) FrontFace() CardFace {
+	return c.Faces()[0]
+}
+
+// Returns true if the card has more than one face
+func (c Card) IsMultiFaced() bool {
+	return len(c.CardFaces) > 1
+}
+
+// Returns true if the faces of the card are printed on different sides
+func (c Card) IsDoubleFaced() bool {
+	switch c.Layout {
+	case LayoutTransform, LayoutModalDFC, LayoutMeld, LayoutDoubleFacedToken, LayoutReversibleCard:
+		return true
+	}
+	return false
+}
+
+// Returns the oracle text of all the faces of the card
+func (c Card) FullOracleText() string {
+	if c.OracleText != "" || len(c.CardFaces) == 0 {
+		return c.OracleText
+	}
+	texts := make([]string, len(c.CardFaces))
+	for i, face := range c.CardFaces {
+		texts[i] = face.OracleText
+	}
+	return strings.Join(texts, "\n//\n")
+}
+
+// Returns the mana cost of all the faces of the card
+func (c Card) FullManaCost() string {
+	if c.ManaCost != "" || len(c.CardFaces) == 0 {
+		return c.ManaCost
+	}
+	costs := []string{}
+	for _, face := range c.CardFaces {
+		if face.ManaCost != "" {
+			costs = append(costs, face.ManaCost)
+		}
+	}
+	return strings.Join(costs, faceSeparator)
+}
+
+// Returns the colors of the card, including the colors of all the faces
+func (c Card) AllColors() []string {
+	if len(c.Colors) != 0 || len(c.CardFaces) == 0 {
+		return c.Colors
+	}
+	result := []string{}
+	has := map[string]bool{}
+	for _, face := range c.CardFaces {
+		for _, color := range face.Colors {
+			if !has[color] {
+				has[color] = true
+				result = append(result, color)
+			}
+		}
+	}
+	return result
+}
+
+// Returns the image urls of each side of the card
+//
+// Single-sided cards (including split, adventure and flip cards) have a single image
+func (c Card) SideImageURIs() []ImageURIs {
+	if c.ImageUris.Small != "" || c.ImageUris.Normal != "" || c.ImageUris.Large != "" {
+		return []ImageURIs{c.ImageUris}
+	}
+	result := []ImageURIs{}
+	for _, face := range c.CardFaces {
+		if face.ImageUris.Small != "" || face.ImageUris.Normal != "" || face.ImageUris.Large != "" {
+			result = append(result, face.ImageUris)
+		}
+	}
+	return result
+}
+
+// Returns the face as a card, so that it can be printed and classified
+func (c Card) faceCard(face CardFace) Card {
+	result := c
+	result.Name = face.Name
+	result.ManaCost = face.ManaCost
+	result.TypeLine = face.TypeLine
+	result.OracleText = face.OracleText
+	result.Power = face.Power
+	result.Toughness = face.Toughness
+	result.Loyalty = face.Loyalty
+	if len(face.Colors) != 0 {
+		result.Colors = face.Colors
+	}
+	result.CardFaces = nil
+	return result
+}
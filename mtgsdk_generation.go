@@ -1,6 +1,7 @@
 package mtgsdk
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"sort"
@@ -21,20 +22,25 @@ const (
 )
 
 // Generates a commander deck for a card (the card has to be a legendary creature)
-func (c Card) GenerateCommanderDeck(params map[string]interface{}, offline bool) (*Deck, error) {
-	if !(c.IsCreature() && c.IsLegendary()) {
-		return nil, fmt.Errorf("mtgsdk - %s is not a legendary creature", c.Name)
+func (c *Client) GenerateCommanderDeck(commander Card, params map[string]interface{}, offline bool) (*Deck, error) {
+	return c.GenerateCommanderDeckContext(context.Background(), commander, params, offline)
+}
+
+// Same as GenerateCommanderDeck, but uses the context for the network requests
+func (c *Client) GenerateCommanderDeckContext(ctx context.Context, commander Card, params map[string]interface{}, offline bool) (*Deck, error) {
+	if !(commander.IsCreature() && commander.IsLegendary()) {
+		return nil, fmt.Errorf("mtgsdk - %s is not a legendary creature", commander.Name)
 	}
-	log.Printf("Generating deck for %s", c.Name)
-	result := CreateDeck(fmt.Sprintf("Commander deck for %s", c.Name))
+	log.Printf("Generating deck for %s", commander.Name)
+	result := CreateDeck(fmt.Sprintf("Commander deck for %s", commander.Name))
 	// add the commander itself
-	result.AddSingletonCard(&c)
+	result.AddToZone(ZoneCommander, &commander, 1)
 	// add staples
-	staples, err := GetEDHRECStaples(offline)
+	staples, err := c.GetEDHRECStaplesContext(ctx, offline)
 	if err != nil {
 		return nil, err
 	}
-	unsortedRecc, err := reccomendCards(c.ID, offline)
+	unsortedRecc, err := c.reccomendCards(ctx, commander.ID, offline)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +67,7 @@ func (c Card) GenerateCommanderDeck(params map[string]interface{}, offline bool)
 		if lr == 0 {
 			break
 		}
-		if c.MatchesColorIdentity(card.ColorIdentity) {
+		if commander.MatchesColorIdentity(card.ColorIdentity) {
 			if card.IsLand() {
 				if result.AddSingletonCard(&card) {
 					lr--
@@ -92,7 +98,7 @@ func (c Card) GenerateCommanderDeck(params map[string]interface{}, offline bool)
 		rr = amount.(int)
 	}
 	for _, card := range staples {
-		if rcards != 0 && c.MatchesColorIdentity(card.ColorIdentity) {
+		if rcards != 0 && commander.MatchesColorIdentity(card.ColorIdentity) {
 			add := false
 			if rampr != 0 && card.IsRamp() {
 				rampr--
@@ -138,16 +144,14 @@ func (c Card) GenerateCommanderDeck(params map[string]interface{}, offline bool)
 		return nil, err
 	}
 	for lname, amount := range blrecc {
-		cards, err := GetCards(map[string]string{CardNameKey: lname}, offline)
+		if amount == 0 {
+			continue
+		}
+		card, err := c.resolveName(ctx, lname, NameExact, offline)
 		if err != nil {
 			return nil, err
 		}
-		for _, card := range cards {
-			if amount != 0 && card.Name == lname {
-				result.AddCard(&card, amount)
-				break
-			}
-		}
+		result.AddCard(&card, amount)
 	}
 	return result, nil
 }
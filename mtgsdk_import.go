@@ -0,0 +1,553 @@
+package mtgsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A deck file format
+type FileFormat string
+
+const (
+	FileFormatText       FileFormat = "text"       // Plain decklist (4 Lightning Bolt), see ParseDecklist
+	FileFormatArena      FileFormat = "arena"      // MTG Arena export (4 Lightning Bolt (M10) 146)
+	FileFormatMTGO       FileFormat = "mtgo"       // MTGO .dek xml
+	FileFormatCockatrice FileFormat = "cockatrice" // Cockatrice .cod xml
+	FileFormatForge      FileFormat = "forge"      // Forge .dck
+	FileFormatMoxfield   FileFormat = "moxfield"   // Moxfield csv
+	FileFormatArchidekt  FileFormat = "archidekt"  // Archidekt csv
+	FileFormatDeckbox    FileFormat = "deckbox"    // Deckbox csv (the set names are resolved to set codes)
+	FileFormatTappedOut  FileFormat = "tappedout"  // TappedOut text (4x Lightning Bolt (M10) *F*)
+	FileFormatCSV        FileFormat = "csv"        // Generic csv with printings, finishes and prices
+)
+
+var (
+	// The importers of the file formats (format -- importer)
+	importers = map[FileFormat]func(data []byte) (importedDeck, error){
+		FileFormatText:       importText,
+		FileFormatArena:      importText,
+		FileFormatMTGO:       importMTGO,
+		FileFormatCockatrice: importCockatrice,
+		FileFormatForge:      importForge,
+		FileFormatMoxfield:   importCSV(FileFormatMoxfield),
+		FileFormatArchidekt:  importCSV(FileFormatArchidekt),
+		FileFormatDeckbox:    importCSV(FileFormatDeckbox),
+		FileFormatTappedOut:  importText,
+		FileFormatCSV:        importCSV(FileFormatCSV),
+	}
+
+	// The columns of the csv formats
+	csvLayouts = map[FileFormat]csvLayout{
+		FileFormatMoxfield:  {amount: "count", name: "name", set: "edition", number: "collector number", finish: "foil", zone: "board"},
+		FileFormatArchidekt: {amount: "quantity", name: "name", set: "edition code", number: "collector number", finish: "finish", zone: "category", id: "scryfall id"},
+		FileFormatDeckbox:   {amount: "count", name: "name", setName: "edition", number: "card number", finish: "foil"},
+		FileFormatCSV:       {amount: "count", name: "name", set: "set", number: "collector number", finish: "finish", zone: "board", id: "scryfall id"},
+	}
+
+	// The csv formats in the order they are detected
+	csvFormats = []FileFormat{FileFormatCSV, FileFormatMoxfield, FileFormatArchidekt, FileFormatDeckbox}
+
+	// Matches a forge section header ([metadata], [Main])
+	forgeSectionRe = regexp.MustCompile(`^\[(\w+)\]$`)
+
+	// Matches a forge card line (4 Lightning Bolt|M10|1)
+	forgeLineRe = regexp.MustCompile(`^(\d+)\s+([^|]+?)(?:\|([A-Za-z0-9]+))?(?:\|\d+)?$`)
+
+	// The deck zones of the forge sections (lower case section -- zone), cards in other sections are skipped
+	forgeSections = map[string]Zone{
+		"commander": ZoneCommander,
+		"main":      ZoneMain,
+		"sideboard": ZoneSideboard,
+	}
+
+	// The deck zones of the cockatrice zones (zone name -- zone), cards in other zones (tokens) are skipped
+	cockatriceZones = map[string]Zone{
+		"main": ZoneMain,
+		"side": ZoneSideboard,
+	}
+
+	// Matches a line with a set code and a collector number (4 Lightning Bolt (M10) 146)
+	arenaLineRe = regexp.MustCompile(`(?m)\([A-Za-z0-9]+\)\s+[A-Za-z0-9★†-]+\s*(?:\*[FE]\*)?\s*$`)
+)
+
+// The decklist of an imported deck file
+type importedDeck struct {
+	name  string         // The name of the deck (empty if the file doesn't have one)
+	notes string         // The notes of the deck
+	lines []DecklistLine // The card lines
+}
+
+// The columns of a csv format (lower case headers, empty if the format doesn't have the column)
+type csvLayout struct {
+	amount  string // The amount of copies
+	name    string // The name of the card
+	set     string // The set code
+	setName string // The set name (for the formats without set codes)
+	number  string // The collector number
+	finish  string // The finish
+	zone    string // The board or the category of the card
+	id      string // The scryfall id
+}
+
+// Returns the file format of the deck file
+//
+// Xml files are detected by their root element, forge files by their sections and csv files by their header.
+// Decklists with set codes and collector numbers are detected as arena exports, everything else as plain text
+func DetectFileFormat(data []byte) FileFormat {
+	text := strings.TrimSpace(strings.TrimPrefix(string(data), "\ufeff"))
+	switch {
+	case strings.Contains(text, "<cockatrice_deck"):
+		return FileFormatCockatrice
+	case strings.HasPrefix(text, "<") && strings.Contains(text, "<Deck"):
+		return FileFormatMTGO
+	}
+	firstLine := strings.TrimSpace(strings.SplitN(text, "\n", 2)[0])
+	if match := forgeSectionRe.FindStringSubmatch(firstLine); match != nil {
+		if _, has := forgeSections[strings.ToLower(match[1])]; has || strings.EqualFold(match[1], "metadata") {
+			return FileFormatForge
+		}
+	}
+	if format, ok := detectCSVFormat(firstLine); ok {
+		return format
+	}
+	if arenaLineRe.MatchString(text) {
+		return FileFormatArena
+	}
+	return FileFormatText
+}
+
+// Returns the csv format with the header
+func detectCSVFormat(header string) (FileFormat, bool) {
+	if !strings.Contains(header, ",") {
+		return "", false
+	}
+	record, err := csv.NewReader(strings.NewReader(header)).Read()
+	if err != nil {
+		return "", false
+	}
+	columns := csvColumns(record)
+	for _, format := range csvFormats {
+		layout := csvLayouts[format]
+		_, hasAmount := columns[layout.amount]
+		_, hasName := columns[layout.name]
+		_, hasNumber := columns[layout.number]
+		_, hasSet := columns[layout.set]
+		if hasAmount && hasName && hasNumber && (hasSet || layout.set == "") {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// Returns the indices of the csv columns (lower case header -- index)
+func csvColumns(header []string) map[string]int {
+	result := map[string]int{}
+	for i, column := range header {
+		result[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = i
+	}
+	return result
+}
+
+// Returns the number of the line at the byte offset (starting from 1)
+func lineAt(data []byte, offset int64) int {
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// Returns the finish written in a csv column (foil, etched, anything else is nonfoil)
+func parseFinish(value string) Finish {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "foil", "true", "yes":
+		return FinishFoil
+	case "etched":
+		return FinishEtched
+	}
+	return FinishNonfoil
+}
+
+// Returns the zone of the board or category name (main deck if unknown)
+//
+// Archidekt categories can be separated by commas, the first one that names a zone is used
+func parseBoard(value string) Zone {
+	for _, board := range strings.Split(value, ",") {
+		if zone, has := sectionHeaders[strings.ToLower(strings.TrimSpace(board))]; has {
+			return zone
+		}
+	}
+	return ZoneMain
+}
+
+// Imports a plain text or an arena decklist
+//
+// The name of the deck is read from the About section of arena exports (About, Name My Deck)
+func importText(data []byte) (importedDeck, error) {
+	result := importedDeck{}
+	lines := strings.Split(string(data), "\n")
+	inAbout := false
+	for i, line := range lines {
+		text := strings.TrimSpace(strings.TrimPrefix(line, "\ufeff"))
+		if strings.EqualFold(text, "about") {
+			inAbout = true
+			lines[i] = ""
+			continue
+		}
+		if !inAbout {
+			continue
+		}
+		if _, isHeader := parseSectionHeader(text); isHeader || text == "" {
+			inAbout = false
+			continue
+		}
+		// the lines are cleared instead of removed so the line numbers stay the same
+		if strings.HasPrefix(text, "Name ") {
+			result.name = strings.TrimSpace(strings.TrimPrefix(text, "Name "))
+		}
+		lines[i] = ""
+	}
+	var err error
+	result.lines, err = ParseDecklist(strings.NewReader(strings.Join(lines, "\n")))
+	return result, err
+}
+
+// Imports a mtgo .dek file
+//
+// Cards with Sideboard="true" are added to the sideboard
+func importMTGO(data []byte) (importedDeck, error) {
+	type mtgoCard struct {
+		Quantity  int    `xml:"Quantity,attr"`
+		Sideboard bool   `xml:"Sideboard,attr"`
+		Name      string `xml:"Name,attr"`
+	}
+	result := importedDeck{}
+	errs := []error{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return importedDeck{}, fmt.Errorf("mtgsdk - can't parse mtgo deck: %w", err)
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != "Cards" {
+			continue
+		}
+		var card mtgoCard
+		err = decoder.DecodeElement(&card, &element)
+		if err != nil {
+			return importedDeck{}, fmt.Errorf("mtgsdk - can't parse mtgo deck: %w", err)
+		}
+		line := DecklistLine{Line: lineAt(data, offset), Zone: ZoneMain, Amount: card.Quantity, Name: card.Name, Finish: FinishNonfoil}
+		if card.Sideboard {
+			line.Zone = ZoneSideboard
+		}
+		if line.Amount <= 0 || line.Name == "" {
+			errs = append(errs, &ParseError{Line: line.Line, Text: card.Name, Err: fmt.Errorf("mtgsdk - invalid card entry")})
+			continue
+		}
+		result.lines = append(result.lines, line)
+	}
+	if len(errs) != 0 {
+		return result, &MultiError{Errors: errs}
+	}
+	return result, nil
+}
+
+// Imports a cockatrice .cod file
+//
+// The main and side zones are imported, the deck name and the comments become the name and the notes of the deck
+func importCockatrice(data []byte) (importedDeck, error) {
+	type cockatriceCard struct {
+		Number          int    `xml:"number,attr"`
+		Name            string `xml:"name,attr"`
+		SetCode         string `xml:"setShortName,attr"`
+		CollectorNumber string `xml:"collectorNumber,attr"`
+	}
+	result := importedDeck{}
+	errs := []error{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	zone, skip := ZoneMain, false
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return importedDeck{}, fmt.Errorf("mtgsdk - can't parse cockatrice deck: %w", err)
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch element.Name.Local {
+		case "deckname":
+			err = decoder.DecodeElement(&result.name, &element)
+		case "comments":
+			err = decoder.DecodeElement(&result.notes, &element)
+		case "zone":
+			for _, attr := range element.Attr {
+				if attr.Name.Local == "name" {
+					zone, ok = cockatriceZones[attr.Value]
+					skip = !ok
+				}
+			}
+		case "card":
+			var card cockatriceCard
+			err = decoder.DecodeElement(&card, &element)
+			if err != nil || skip {
+				break
+			}
+			line := DecklistLine{
+				Line:            lineAt(data, offset),
+				Zone:            zone,
+				Amount:          card.Number,
+				Name:            card.Name,
+				SetCode:         strings.ToLower(card.SetCode),
+				CollectorNumber: card.CollectorNumber,
+				Finish:          FinishNonfoil,
+			}
+			if line.Amount <= 0 || line.Name == "" {
+				errs = append(errs, &ParseError{Line: line.Line, Text: card.Name, Err: fmt.Errorf("mtgsdk - invalid card entry")})
+				break
+			}
+			result.lines = append(result.lines, line)
+		}
+		if err != nil {
+			return importedDeck{}, fmt.Errorf("mtgsdk - can't parse cockatrice deck: %w", err)
+		}
+	}
+	if len(errs) != 0 {
+		return result, &MultiError{Errors: errs}
+	}
+	return result, nil
+}
+
+// Imports a forge .dck file
+//
+// The Commander, Main and Sideboard sections are imported, the Name and the Description of the metadata become
+// the name and the notes of the deck
+func importForge(data []byte) (importedDeck, error) {
+	result := importedDeck{}
+	errs := []error{}
+	section := ""
+	for i, line := range strings.Split(string(data), "\n") {
+		text := strings.TrimSpace(strings.TrimPrefix(line, "\ufeff"))
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if match := forgeSectionRe.FindStringSubmatch(text); match != nil {
+			section = strings.ToLower(match[1])
+			continue
+		}
+		if section == "metadata" {
+			key, value := text, ""
+			if index := strings.Index(text, "="); index != -1 {
+				key, value = text[:index], text[index+1:]
+			}
+			switch strings.ToLower(key) {
+			case "name":
+				result.name = value
+			case "description":
+				result.notes = value
+			}
+			continue
+		}
+		zone, has := forgeSections[section]
+		if !has {
+			continue
+		}
+		match := forgeLineRe.FindStringSubmatch(text)
+		if match == nil {
+			errs = append(errs, &ParseError{Line: i + 1, Text: text, Err: fmt.Errorf("mtgsdk - can't parse forge line %q", text)})
+			continue
+		}
+		amount, err := strconv.Atoi(match[1])
+		if err != nil || amount <= 0 {
+			errs = append(errs, &ParseError{Line: i + 1, Text: text, Err: fmt.Errorf("mtgsdk - forge line %q has an invalid amount", text)})
+			continue
+		}
+		result.lines = append(result.lines, DecklistLine{
+			Line:    i + 1,
+			Zone:    zone,
+			Amount:  amount,
+			Name:    match[2],
+			SetCode: strings.ToLower(match[3]),
+			Finish:  FinishNonfoil,
+		})
+	}
+	if len(errs) != 0 {
+		return result, &MultiError{Errors: errs}
+	}
+	return result, nil
+}
+
+// Returns the importer of the csv format
+func importCSV(format FileFormat) func(data []byte) (importedDeck, error) {
+	return func(data []byte) (importedDeck, error) {
+		layout := csvLayouts[format]
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+		reader.FieldsPerRecord = -1
+		header, err := reader.Read()
+		if err != nil {
+			return importedDeck{}, fmt.Errorf("mtgsdk - can't read %s csv header: %w", format, err)
+		}
+		columns := csvColumns(header)
+		// returns the value of the column in the record (empty if missing)
+		get := func(record []string, column string) string {
+			index, has := columns[column]
+			if column == "" || !has || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+		result := importedDeck{}
+		errs := []error{}
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				errs = append(errs, err)
+				break
+			}
+			number, _ := reader.FieldPos(0)
+			amount, err := strconv.Atoi(get(record, layout.amount))
+			if err != nil || amount <= 0 {
+				errs = append(errs, &ParseError{Line: number, Text: strings.Join(record, ","), Err: fmt.Errorf("mtgsdk - invalid amount %q", get(record, layout.amount))})
+				continue
+			}
+			line := DecklistLine{
+				Line:            number,
+				Zone:            parseBoard(get(record, layout.zone)),
+				Amount:          amount,
+				Name:            get(record, layout.name),
+				SetCode:         strings.ToLower(get(record, layout.set)),
+				SetName:         get(record, layout.setName),
+				CollectorNumber: get(record, layout.number),
+				Finish:          parseFinish(get(record, layout.finish)),
+				ID:              get(record, layout.id),
+			}
+			if line.Name == "" && line.ID == "" {
+				errs = append(errs, &ParseError{Line: number, Text: strings.Join(record, ","), Err: fmt.Errorf("mtgsdk - missing card name")})
+				continue
+			}
+			result.lines = append(result.lines, line)
+		}
+		if len(errs) != 0 {
+			return result, &MultiError{Errors: errs}
+		}
+		return result, nil
+	}
+}
+
+// Imports the deck in the file format from the reader
+//
+// The cards are looked up by their printings if the format has them, otherwise by their names
+func (c *Client) ImportDeck(r io.Reader, format FileFormat) (Deck, error) {
+	return c.ImportDeckContext(context.Background(), r, format)
+}
+
+// Same as ImportDeck, but uses the context for the network requests
+func (c *Client) ImportDeckContext(ctx context.Context, r io.Reader, format FileFormat) (Deck, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Deck{}, err
+	}
+	return c.importDeck(ctx, data, format)
+}
+
+// Imports the deck file in the file format
+func (c *Client) importDeck(ctx context.Context, data []byte, format FileFormat) (Deck, error) {
+	importer, has := importers[format]
+	if !has {
+		return Deck{}, fmt.Errorf("mtgsdk - unknown deck file format %s", format)
+	}
+	imported, err := importer(data)
+	if err != nil {
+		return Deck{}, err
+	}
+	err = c.resolveSetNames(ctx, imported.lines)
+	if err != nil {
+		return Deck{}, err
+	}
+	result, err := c.deckFromLines(ctx, imported.lines)
+	if err != nil {
+		return Deck{}, err
+	}
+	result.Name = imported.name
+	result.Notes = imported.notes
+	return result, nil
+}
+
+// Sets the set codes of the lines that only have set names (deckbox)
+//
+// The set names are looked up in the scryfall set list. If the list can't be fetched or the set name is unknown,
+// the printing can't be kept and the card is looked up by its name
+func (c *Client) resolveSetNames(ctx context.Context, lines []DecklistLine) error {
+	for i := range lines {
+		line := &lines[i]
+		if line.SetCode != "" || line.SetName == "" {
+			continue
+		}
+		code, found, err := c.setCodeByName(ctx, line.SetName)
+		var dnsError *net.DNSError
+		if errors.As(err, &dnsError) {
+			log.Printf("mtgsdk - failed to connect to host, the printings of the set names can't be kept")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !found {
+			log.Printf("mtgsdk - line %d: unknown set %s, the printing of %s can't be kept", line.Line, line.SetName, line.Name)
+			continue
+		}
+		line.SetCode = code
+	}
+	return nil
+}
+
+// Returns the code of the set with the name
+//
+// Fetches the set list from scryfall on the first call
+func (c *Client) setCodeByName(ctx context.Context, name string) (string, bool, error) {
+	c.setCodesMu.Lock()
+	defer c.setCodesMu.Unlock()
+	if c.setCodes == nil {
+		resp, err := c.get(ctx, c.apiURL+setsPath)
+		if err != nil {
+			return "", false, err
+		}
+		defer resp.Body.Close()
+		var sets struct {
+			Data []struct {
+				Code string `json:"code"`
+				Name string `json:"name"`
+			} `json:"data"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&sets)
+		if err != nil {
+			return "", false, err
+		}
+		c.setCodes = make(map[string]string, len(sets.Data))
+		for _, set := range sets.Data {
+			c.setCodes[strings.ToLower(set.Name)] = set.Code
+		}
+	}
+	code, found := c.setCodes[strings.ToLower(strings.TrimSpace(name))]
+	return code, found, nil
+}
@@ -0,0 +1,131 @@
+package mtgsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportersAndDetection(t *testing.T) {
+	for _, test := range []struct {
+		name     string     // The name of the case
+		data     string     // The deck file
+		format   FileFormat // The expected detected format
+		deckName string     // The expected name of the deck
+		lines    []string   // The expected lines (zone amount name set number finish)
+	}{
+		{
+			name:     "arena",
+			data:     "About\nName Burn\n\nDeck\n4 Lightning Bolt (M10) 146\n1 Fire // Ice (MH2) 290 *F*\n\nSideboard\n2 Duress (M19) 94\n",
+			format:   FileFormatArena,
+			deckName: "Burn",
+			lines:    []string{"Deck 4 Lightning Bolt m10 146 nonfoil", "Deck 1 Fire // Ice mh2 290 foil", "Sideboard 2 Duress m19 94 nonfoil"},
+		},
+		{
+			name: "mtgo",
+			data: "<?xml version=\"1.0\" encoding=\"utf-8\"?>\r\n<Deck xmlns:xsd=\"http://www.w3.org/2001/XMLSchema\">\r\n  <NetDeckID>0</NetDeckID>\r\n" +
+				"  <Cards CatID=\"105\" Quantity=\"4\" Sideboard=\"false\" Name=\"Lightning Bolt\" />\r\n" +
+				"  <Cards CatID=\"104\" Quantity=\"2\" Sideboard=\"true\" Name=\"Duress\" />\r\n</Deck>\r\n",
+			format: FileFormatMTGO,
+			lines:  []string{"Deck 4 Lightning Bolt   nonfoil", "Sideboard 2 Duress   nonfoil"},
+		},
+		{
+			name: "moxfield",
+			data: "\"Count\",\"Tradelist Count\",\"Name\",\"Edition\",\"Condition\",\"Language\",\"Foil\",\"Tags\",\"Last Modified\",\"Collector Number\"\n" +
+				"\"4\",\"4\",\"Lightning Bolt\",\"m10\",\"Near Mint\",\"English\",\"\",\"\",\"2023-01-01\",\"146\"\n" +
+				"\"1\",\"0\",\"Sol Ring\",\"c21\",\"Near Mint\",\"English\",\"foil\",\"\",\"2023-01-01\",\"263\"\n",
+			format: FileFormatMoxfield,
+			lines:  []string{"Deck 4 Lightning Bolt m10 146 nonfoil", "Deck 1 Sol Ring c21 263 foil"},
+		},
+		{
+			name: "deckbox",
+			data: "Count,Tradelist Count,Name,Edition,Card Number,Condition,Language,Foil,Signed,Artist Proof,Altered Art,Misprint,Promo,Textless,My Price\n" +
+				"4,0,Lightning Bolt,Magic 2010,146,Near Mint,English,,,,,,,,\n" +
+				"1,0,Sol Ring,Commander 2021,263,Near Mint,English,foil,,,,,,,\n",
+			format: FileFormatDeckbox,
+			lines:  []string{"Deck 4 Lightning Bolt  146 nonfoil", "Deck 1 Sol Ring  263 foil"},
+		},
+		{
+			// a comma in a card name doesn't make a csv header, and lines without printings are plain text
+			name:   "ambiguous",
+			data:   "1 Atraxa, Praetors' Voice\n4 Lightning Bolt (M10)\n",
+			format: FileFormatText,
+			lines:  []string{"Deck 1 Atraxa, Praetors' Voice   nonfoil", "Deck 4 Lightning Bolt m10  nonfoil"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if format := DetectFileFormat([]byte(test.data)); format != test.format {
+				t.Fatalf("expected the format %s, got %s", test.format, format)
+			}
+			imported, err := importers[test.format]([]byte(test.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if imported.name != test.deckName {
+				t.Fatalf("expected the deck name %q, got %q", test.deckName, imported.name)
+			}
+			lines := []string{}
+			for _, line := range imported.lines {
+				lines = append(lines, fmt.Sprintf("%s %d %s %s %s %s", line.Zone, line.Amount, line.Name, line.SetCode, line.CollectorNumber, line.Finish))
+			}
+			if !reflect.DeepEqual(lines, test.lines) {
+				t.Fatalf("expected the lines\n%q\ngot\n%q", test.lines, lines)
+			}
+		})
+	}
+}
+
+func TestImportDeckboxSetNames(t *testing.T) {
+	printings := map[CardIdentifier]Card{
+		IdentifierByPrinting("c21", "263"): {ID: "sol-ring-c21", Name: "Sol Ring", Set: "c21", CollectorNumber: "263"},
+	}
+	byName := map[string]Card{
+		"Forest": {ID: "forest", Name: "Forest", Set: "m21", CollectorNumber: "274"},
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case setsPath:
+			w.Write([]byte(`{"data": [{"code": "c21", "name": "Commander 2021"}, {"code": "m21", "name": "Core Set 2021"}]}`))
+		case cardCollectionPath:
+			var body collectionRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			response := collectionResponse{Data: []Card{}, NotFound: []CardIdentifier{}}
+			for _, identifier := range body.Identifiers {
+				card, has := printings[identifier]
+				if !has {
+					card, has = byName[identifier.Name]
+				}
+				if !has {
+					response.NotFound = append(response.NotFound, identifier)
+					continue
+				}
+				response.Data = append(response.Data, card)
+			}
+			json.NewEncoder(w).Encode(response)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	data := "Count,Tradelist Count,Name,Edition,Card Number,Condition,Language,Foil\n" +
+		"1,0,Sol Ring,Commander 2021,263,Near Mint,English,foil\n" +
+		"10,0,Forest,Unknown Set,999,Near Mint,English,\n"
+	deck, err := client.ImportDeck(strings.NewReader(data), FileFormatDeckbox)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := deck.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %v", entries)
+	}
+	if entries[0].Card.ID != "sol-ring-c21" || entries[0].Finish != FinishFoil {
+		t.Fatalf("expected the foil Sol Ring from Commander 2021, got %s (%s)", entries[0].Card.ID, entries[0].Finish)
+	}
+	// the printing of the unknown set can't be kept, so the card is looked up by its name
+	if entries[1].Card.ID != "forest" || entries[1].Amount != 10 {
+		t.Fatalf("expected 10 Forests, got %d %s", entries[1].Amount, entries[1].Card.ID)
+	}
+}
@@ -0,0 +1,256 @@
+package mtgsdk
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// A game format (used in scryfall api)
+type Format string
+
+const (
+	FormatStandard    Format = "standard"
+	FormatPioneer     Format = "pioneer"
+	FormatModern      Format = "modern"
+	FormatLegacy      Format = "legacy"
+	FormatVintage     Format = "vintage"
+	FormatPauper      Format = "pauper"
+	FormatCommander   Format = "commander"
+	FormatBrawl       Format = "brawl"
+	FormatOathbreaker Format = "oathbreaker"
+)
+
+// The legality of a card in a format (used in scryfall api)
+type Legality string
+
+const (
+	Legal      Legality = "legal"
+	NotLegal   Legality = "not_legal"
+	Restricted Legality = "restricted"
+	Banned     Legality = "banned"
+)
+
+// The map of format legalities of a card (format -- legality)
+type Legalities map[Format]Legality
+
+// The rules of a format that are checked by Deck.Validate
+type formatRules struct {
+	minSize       int  // The minimum amount of cards in the main deck (including the commanders)
+	exactSize     bool // True if the deck must have exactly minSize cards
+	maxSideboard  int  // The maximum amount of cards in the sideboard (0 if the format doesn't have a sideboard)
+	copyLimit     int  // The maximum amount of copies of a card
+	maxCommanders int  // The maximum amount of commanders (0 if the format doesn't have commanders)
+}
+
+var (
+	// The rules of the supported formats
+	formats = map[Format]formatRules{
+		FormatStandard:    {minSize: 60, maxSideboard: 15, copyLimit: 4},
+		FormatPioneer:     {minSize: 60, maxSideboard: 15, copyLimit: 4},
+		FormatModern:      {minSize: 60, maxSideboard: 15, copyLimit: 4},
+		FormatLegacy:      {minSize: 60, maxSideboard: 15, copyLimit: 4},
+		FormatVintage:     {minSize: 60, maxSideboard: 15, copyLimit: 4},
+		FormatPauper:      {minSize: 60, maxSideboard: 15, copyLimit: 4},
+		FormatCommander:   {minSize: 100, exactSize: true, copyLimit: 1, maxCommanders: 2},
+		FormatBrawl:       {minSize: 100, exactSize: true, copyLimit: 1, maxCommanders: 1},
+		FormatOathbreaker: {minSize: 60, exactSize: true, copyLimit: 1, maxCommanders: 2},
+	}
+
+	// Matches the oracle text of the cards that ignore the copy limit (Relentless Rats, Seven Dwarves, ...)
+	copyLimitExceptionRe = regexp.MustCompile(`A deck can have (any number|up to (\w+)) cards named`)
+
+	// The numbers used in the copy limit exceptions
+	copyLimitNumbers = map[string]int{
+		"two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	}
+)
+
+// Returns the legality of the card in the format
+//
+// Returns an empty legality if the card doesn't have legality data
+func (c Card) LegalityIn(format Format) Legality {
+	return c.Legalities[format]
+}
+
+// Returns true if the card is legal (or restricted) in the format
+func (c Card) IsLegalIn(format Format) bool {
+	legality := c.LegalityIn(format)
+	return legality == Legal || legality == Restricted
+}
+
+// Returns the maximum amount of copies of the card in a deck of the format
+//
+// Returns -1 if the amount is not limited
+func (c Card) copyLimit(format Format, rules formatRules) int {
+	if c.IsBasicLand() {
+		return -1
+	}
+	if match := copyLimitExceptionRe.FindStringSubmatch(c.FullOracleText()); match != nil {
+		if match[2] == "" {
+			return -1
+		}
+		if amount, has := copyLimitNumbers[match[2]]; has {
+			return amount
+		}
+	}
+	if c.LegalityIn(format) == Restricted {
+		return 1
+	}
+	return rules.copyLimit
+}
+
+// A kind of deck legality violation
+type ViolationKind string
+
+const (
+	ViolationNotLegal      ViolationKind = "not_legal"      // The card is not legal in the format
+	ViolationBanned        ViolationKind = "banned"         // The card is banned in the format
+	ViolationRestricted    ViolationKind = "restricted"     // The card is restricted and has more than one copy
+	ViolationCopyLimit     ViolationKind = "copy_limit"     // The card has too many copies
+	ViolationDeckSize      ViolationKind = "deck_size"      // The main deck has a wrong amount of cards
+	ViolationSideboardSize ViolationKind = "sideboard_size" // The sideboard has too many cards
+	ViolationCommander     ViolationKind = "commander"      // The deck has a missing or invalid commander
+	ViolationColorIdentity ViolationKind = "color_identity" // The card is outside of the commander's color identity
+)
+
+// A deck legality violation
+type Violation struct {
+	Kind    ViolationKind // The kind of the violation
+	Card    string        // The name of the card (empty if the violation is not about a card)
+	Message string        // The description of the violation
+}
+
+func (v Violation) Error() string {
+	return v.Message
+}
+
+// Checks whether the deck can be played in the format
+//
+// The commanders count toward the deck size. The companion counts toward the sideboard size in formats without
+// commanders. The maybeboard is not checked, and neither is the sideboard in formats without one (commander, brawl, oathbreaker).
+// Returns the found violations (empty if the deck is legal). Cards without legality data are not checked for bans
+func (d Deck) Validate(format Format) ([]Violation, error) {
+	rules, has := formats[format]
+	if !has {
+		return nil, fmt.Errorf("mtgsdk - unknown format %s", format)
+	}
+	result := []Violation{}
+	add := func(kind ViolationKind, card string, message string, args ...interface{}) {
+		result = append(result, Violation{Kind: kind, Card: card, Message: fmt.Sprintf(message, args...)})
+	}
+	// deck size
+	size := d.ZoneSize(ZoneCommander) + d.ZoneSize(ZoneMain)
+	if rules.exactSize && size != rules.minSize {
+		add(ViolationDeckSize, "", "deck has %d cards, %s requires exactly %d", size, format, rules.minSize)
+	}
+	if !rules.exactSize && size < rules.minSize {
+		add(ViolationDeckSize, "", "deck has %d cards, %s requires at least %d", size, format, rules.minSize)
+	}
+	// sideboard size
+	zones := []Zone{ZoneCommander, ZoneCompanion, ZoneMain}
+	if rules.maxSideboard > 0 {
+		zones = append(zones, ZoneSideboard)
+		sideboardSize := d.ZoneSize(ZoneSideboard)
+		if rules.maxCommanders == 0 {
+			sideboardSize += d.ZoneSize(ZoneCompanion)
+		}
+		if sideboardSize > rules.maxSideboard {
+			add(ViolationSideboardSize, "", "sideboard has %d cards, %s allows at most %d", sideboardSize, format, rules.maxSideboard)
+		}
+	}
+	// legality and copy limits (copies are counted by name across the zones)
+	copies := map[string]int{}
+	all := []Card{}
+	d.eachInZones(zones, func(card Card, amount int) {
+		if _, has := copies[card.Name]; !has {
+			all = append(all, card)
+		}
+		copies[card.Name] += amount
+	})
+	for _, card := range all {
+		switch card.LegalityIn(format) {
+		case NotLegal:
+			add(ViolationNotLegal, card.Name, "%s is not legal in %s", card.Name, format)
+			continue
+		case Banned:
+			add(ViolationBanned, card.Name, "%s is banned in %s", card.Name, format)
+			continue
+		}
+		limit := card.copyLimit(format, rules)
+		if limit < 0 || copies[card.Name] <= limit {
+			continue
+		}
+		if card.LegalityIn(format) == Restricted {
+			add(ViolationRestricted, card.Name, "%s is restricted in %s, but the deck has %d copies", card.Name, format, copies[card.Name])
+			continue
+		}
+		add(ViolationCopyLimit, card.Name, "deck has %d copies of %s, %s allows at most %d", copies[card.Name], card.Name, format, limit)
+	}
+	if rules.maxCommanders == 0 {
+		return result, nil
+	}
+	// commanders
+	commanders := d.GetCommanders()
+	if len(commanders) == 0 {
+		add(ViolationCommander, "", "%s deck doesn't have a commander", format)
+		return result, nil
+	}
+	if len(commanders) > rules.maxCommanders {
+		add(ViolationCommander, "", "deck has %d commanders, %s allows at most %d", len(commanders), format, rules.maxCommanders)
+	}
+	// backgrounds and other partners that aren't legendary creatures are allowed as a part of a pair
+	pair := len(commanders) == 2 && format != FormatOathbreaker && IsCommanderPair(commanders[0], commanders[1])
+	identity := map[string]bool{}
+	for _, commander := range commanders {
+		if !pair && !canBeCommander(commander, format) {
+			add(ViolationCommander, commander.Name, "%s can't be a commander in %s", commander.Name, format)
+		}
+		for _, color := range commander.ColorIdentity {
+			identity[color] = true
+		}
+	}
+	if len(commanders) == 2 {
+		if format == FormatOathbreaker {
+			if countPlaneswalkers(commanders) != 1 {
+				add(ViolationCommander, "", "%s deck needs one oathbreaker and one signature spell", format)
+			}
+		} else if !pair {
+			add(ViolationCommander, "", "%s and %s can't be commanders together", commanders[0].Name, commanders[1].Name)
+		}
+	}
+	for _, card := range all {
+		colors := map[string]bool{}
+		for _, color := range card.ColorIdentity {
+			colors[color] = true
+		}
+		if !isColorSubset(colors, identity) {
+			add(ViolationColorIdentity, card.Name, "%s is outside of the commander's color identity", card.Name)
+		}
+	}
+	return result, nil
+}
+
+// Returns the amount of planeswalkers among the cards
+func countPlaneswalkers(cards []Card) int {
+	result := 0
+	for _, card := range cards {
+		if strings.Contains(card.TypeLine, "Planeswalker") {
+			result++
+		}
+	}
+	return result
+}
+
+// Returns true if the card can lead a deck of the format
+//
+// In oathbreaker the commanders are the oathbreaker planeswalker and its signature spell
+func canBeCommander(card Card, format Format) bool {
+	if format == FormatOathbreaker {
+		return strings.Contains(card.TypeLine, "Planeswalker") || strings.Contains(card.TypeLine, "Instant") || strings.Contains(card.TypeLine, "Sorcery")
+	}
+	if format == FormatBrawl && card.IsLegendary() && strings.Contains(card.TypeLine, "Planeswalker") {
+		return true
+	}
+	return matchIs(card, "commander")
+}
@@ -0,0 +1,57 @@
+package mtgsdk
+
+import "testing"
+
+func TestCopyLimitBasicLands(t *testing.T) {
+	rules := formats[FormatCommander]
+	for _, card := range []Card{
+		{Name: "Forest", TypeLine: "Basic Land — Forest"},
+		{Name: "Snow-Covered Forest", TypeLine: "Basic Snow Land — Forest"},
+		{Name: "Snow-Covered Wastes", TypeLine: "Basic Snow Land"},
+	} {
+		if limit := card.copyLimit(FormatCommander, rules); limit != -1 {
+			t.Errorf("%s: expected no copy limit, got %d", card.Name, limit)
+		}
+	}
+	for _, card := range []Card{
+		{Name: "Sol Ring", TypeLine: "Artifact"},
+		{Name: "Dryad Arbor", TypeLine: "Land Creature — Forest Dryad"},
+		{Name: "Arctic Treeline", TypeLine: "Snow Land"},
+	} {
+		if limit := card.copyLimit(FormatCommander, rules); limit != rules.copyLimit {
+			t.Errorf("%s: expected the copy limit %d, got %d", card.Name, rules.copyLimit, limit)
+		}
+	}
+}
+
+func TestValidateSideboardFormats(t *testing.T) {
+	commander := Card{ID: "titania", Name: "Titania, Protector of Argoth", TypeLine: "Legendary Creature — Elemental", ColorIdentity: []string{"G"}}
+	forest := Card{ID: "forest", Name: "Forest", TypeLine: "Basic Land — Forest"}
+	solRing := Card{ID: "sol-ring", Name: "Sol Ring", TypeLine: "Artifact"}
+	bolt := Card{ID: "bolt", Name: "Lightning Bolt", TypeLine: "Instant", ColorIdentity: []string{"R"}}
+	deck := CreateDeck("titania")
+	deck.SetCommanders(commander)
+	deck.AddCard(&forest, 99)
+	// the sideboard of a commander deck isn't played, so its size, copies and colors aren't checked
+	deck.AddSideboardCard(&solRing, 2)
+	deck.AddSideboardCard(&bolt, 14)
+	violations, err := deck.Validate(FormatCommander)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("expected a legal commander deck, got %v", violations)
+	}
+
+	violations, err = deck.Validate(FormatModern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, violation := range violations {
+		found = found || violation.Kind == ViolationSideboardSize
+	}
+	if !found {
+		t.Fatalf("expected the 16 card sideboard to be too big for modern, got %v", violations)
+	}
+}
@@ -0,0 +1,244 @@
+package mtgsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	cardNamedPath    = "/cards/named?" // the path for looking up cards by name
+	minFuzzyDistance = 2               // the edit distance that is always allowed for fuzzy names
+)
+
+// A mode of card name resolution
+type NameMode struct {
+	Fuzzy bool   // True if the name can be misspelled or partial
+	Set   string // The set code the card has to be printed in (empty for any set)
+}
+
+var (
+	NameExact = NameMode{}            // The name has to match exactly (ignoring case and punctuation)
+	NameFuzzy = NameMode{Fuzzy: true} // The name can be misspelled
+)
+
+// Returns the mode that only resolves cards printed in the set
+func (m NameMode) InSet(setCode string) NameMode {
+	m.Set = setCode
+	return m
+}
+
+// Returns the card with the name
+//
+// If can't connect to scryfall, resolves the name against the local cards. Returns ErrNotFound if no card matches
+// and ErrAmbiguous if a fuzzy name matches several cards
+func (c *Client) ResolveName(name string, mode NameMode) (Card, error) {
+	return c.ResolveNameContext(context.Background(), name, mode)
+}
+
+// Same as ResolveName, but uses the context for the network requests
+func (c *Client) ResolveNameContext(ctx context.Context, name string, mode NameMode) (Card, error) {
+	params := url.Values{}
+	if mode.Fuzzy {
+		params.Set("fuzzy", name)
+	} else {
+		params.Set("exact", name)
+	}
+	if mode.Set != "" {
+		params.Set("set", strings.ToLower(mode.Set))
+	}
+	resp, err := c.get(ctx, c.apiURL+cardNamedPath+params.Encode())
+	var dnsError *net.DNSError
+	if errors.As(err, &dnsError) {
+		log.Println("mtgsdk - failed to connect to host, resolving the name in the card store")
+		return c.ResolveNameOffline(name, mode)
+	}
+	if err != nil {
+		return Card{}, err
+	}
+	defer resp.Body.Close()
+	var card Card
+	err = json.NewDecoder(resp.Body).Decode(&card)
+	if err != nil {
+		return Card{}, err
+	}
+	if card.ID == "" {
+		return Card{}, fmt.Errorf("mtgsdk - fetched card for name %s, but it doesn't have an id", name)
+	}
+	err = c.saveCard(card)
+	if err != nil {
+		return Card{}, err
+	}
+	return card, nil
+}
+
+// Returns the card with the name from the card store
+//
+// Names are compared ignoring case and punctuation. In fuzzy mode, if no name matches exactly,
+// returns the card with the closest name (by edit distance)
+func (c *Client) ResolveNameOffline(name string, mode NameMode) (Card, error) {
+	target := normalizeName(name)
+	inSet := func(card Card) bool {
+		return mode.Set == "" || strings.EqualFold(card.Set, mode.Set)
+	}
+	// fast path for names that are spelled correctly
+	cards, err := c.store.FindByName(name)
+	if err != nil {
+		return Card{}, err
+	}
+	if card, found := pickPrinting(cards, inSet); found {
+		return card, nil
+	}
+	exact := []Card{}
+	closest := []Card{}
+	closestDistance := -1
+	maxDistance := len(target) / 4
+	if maxDistance < minFuzzyDistance {
+		maxDistance = minFuzzyDistance
+	}
+	err = c.store.Each(func(card Card) bool {
+		if !inSet(card) {
+			return true
+		}
+		distance := -1
+		for _, cardName := range cardNames(card) {
+			normalized := normalizeName(cardName)
+			if normalized == target {
+				exact = append(exact, card)
+				return true
+			}
+			if !mode.Fuzzy {
+				continue
+			}
+			d := editDistance(normalized, target)
+			if distance == -1 || d < distance {
+				distance = d
+			}
+		}
+		if distance == -1 || distance > maxDistance {
+			return true
+		}
+		if closestDistance == -1 || distance < closestDistance {
+			closest = closest[:0]
+			closestDistance = distance
+		}
+		if distance == closestDistance {
+			closest = append(closest, card)
+		}
+		return true
+	})
+	if err != nil {
+		return Card{}, err
+	}
+	if card, found := pickPrinting(exact, inSet); found {
+		return card, nil
+	}
+	if len(closest) == 0 {
+		return Card{}, fmt.Errorf("mtgsdk - card %s is not in the card store: %w", name, ErrNotFound)
+	}
+	names := map[string]bool{}
+	for _, card := range closest {
+		names[card.Name] = true
+	}
+	if len(names) > 1 {
+		return Card{}, fmt.Errorf("mtgsdk - name %s matches %d cards: %w", name, len(names), ErrAmbiguous)
+	}
+	card, _ := pickPrinting(closest, inSet)
+	return card, nil
+}
+
+// Resolves the name online or offline
+func (c *Client) resolveName(ctx context.Context, name string, mode NameMode, offline bool) (Card, error) {
+	if offline {
+		return c.ResolveNameOffline(name, mode)
+	}
+	return c.ResolveNameContext(ctx, name, mode)
+}
+
+// Returns the printing of the card with the smallest id that passes the filter
+//
+// The printing is picked by id, so that the same card is returned for every lookup
+func pickPrinting(cards []Card, filter func(card Card) bool) (Card, bool) {
+	filtered := []Card{}
+	for _, card := range cards {
+		if filter(card) {
+			filtered = append(filtered, card)
+		}
+	}
+	if len(filtered) == 0 {
+		return Card{}, false
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].ID < filtered[j].ID
+	})
+	return filtered[0], true
+}
+
+// Returns the names the card can be looked up by (the full name and the names of the faces)
+func cardNames(card Card) []string {
+	result := []string{card.Name}
+	for _, face := range card.CardFaces {
+		result = append(result, face.Name)
+	}
+	return result
+}
+
+// Returns the name in lower case without punctuation and extra spaces
+func normalizeName(name string) string {
+	var builder strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && builder.Len() != 0 {
+				builder.WriteRune(' ')
+			}
+			space = false
+			builder.WriteRune(r)
+		case unicode.IsSpace(r):
+			space = true
+		}
+	}
+	return builder.String()
+}
+
+// Returns the levenshtein distance between the strings
+func editDistance(a string, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// Returns the smallest of the numbers
+func minInt(first int, rest ...int) int {
+	result := first
+	for _, n := range rest {
+		if n < result {
+			result = n
+		}
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+package mtgsdk
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveNameOffline(t *testing.T) {
+	client := newTestClient(t, nil)
+	err := client.Store().PutBatch([]Card{
+		{ID: "sol-ring-lea", Name: "Sol Ring", Set: "lea"},
+		{ID: "sol-ring-c21", Name: "Sol Ring", Set: "c21"},
+		{ID: "atraxa", Name: "Atraxa, Praetors' Voice", Set: "2x2"},
+		{ID: "fire-ice", Name: "Fire // Ice", Set: "mh2", CardFaces: []CardFace{{Name: "Fire"}, {Name: "Ice"}}},
+		{ID: "bolt", Name: "Lightning Bolt", Set: "m10"},
+		{ID: "shock", Name: "Shock", Set: "m19"},
+		{ID: "smock", Name: "Smock", Set: "m19"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name     string   // The name to resolve
+		mode     NameMode // The mode of the resolution
+		expected string   // The id of the expected card (empty if an error is expected)
+		err      error    // The expected error
+	}{
+		{"Sol Ring", NameExact, "sol-ring-c21", nil},
+		{"sOL rING", NameExact, "sol-ring-c21", nil},
+		{"Sol Ring", NameExact.InSet("LEA"), "sol-ring-lea", nil},
+		{"Sol Ring", NameExact.InSet("m21"), "", ErrNotFound},
+		// the fast path misses these, so the whole store is scanned
+		{"atraxa praetors voice", NameExact, "atraxa", nil},
+		{"Ice", NameExact, "fire-ice", nil},
+		{"Lightnig Bolt", NameFuzzy, "bolt", nil},
+		{"Lightnig Bolt", NameExact, "", ErrNotFound},
+		{"Sxock", NameFuzzy, "", ErrAmbiguous},
+		{"Counterspell", NameFuzzy, "", ErrNotFound},
+	} {
+		card, err := client.ResolveNameOffline(test.name, test.mode)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%s %+v: expected %v, got %v (%s)", test.name, test.mode, test.err, err, card.ID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %+v: %v", test.name, test.mode, err)
+			continue
+		}
+		if card.ID != test.expected {
+			t.Errorf("%s %+v: expected %s, got %s", test.name, test.mode, test.expected, card.ID)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package mtgsdk
+
+import (
+	"sort"
+	"strings"
+)
+
+// An order of the cards in a deck
+type DeckOrder int
+
+const (
+	OrderInsertion DeckOrder = iota // The order the cards were added in
+	OrderName                       // Alphabetical order
+	OrderType                       // Grouped by card type (creatures, planeswalkers, artifacts, enchantments, instants, sorceries, battles, lands), then by name
+	OrderCMC                        // By mana value, then by name
+)
+
+var (
+	// The card types in the order they are grouped by
+	typeOrder = []string{"Creature", "Planeswalker", "Artifact", "Enchantment", "Instant", "Sorcery", "Battle", "Land"}
+)
+
+// Sets the order in which the cards of the deck are returned, printed and saved
+func (d *Deck) SetOrder(order DeckOrder) {
+	d.order = order
+}
+
+// Returns the order of the cards of the deck
+func (d Deck) GetOrder() DeckOrder {
+	return d.order
+}
+
+// Returns the index of the group of the card in the type order
+//
+// Only the front face is checked, so modal double-faced spells with a land back are grouped as spells
+func typeRank(card Card) int {
+	typeLine := card.FrontFace().TypeLine
+	for i, t := range typeOrder {
+		if strings.Contains(typeLine, t) {
+			return i
+		}
+	}
+	return len(typeOrder)
+}
+
+// Returns the function that compares two cards in the order (nil for the insertion order)
+func cardLess(order DeckOrder) func(a Card, b Card) bool {
+	byName := func(a Card, b Card) bool {
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	}
+	switch order {
+	case OrderName:
+		return byName
+	case OrderType:
+		return func(a Card, b Card) bool {
+			ra, rb := typeRank(a), typeRank(b)
+			if ra != rb {
+				return ra < rb
+			}
+			return byName(a, b)
+		}
+	case OrderCMC:
+		return func(a Card, b Card) bool {
+			if a.Cmc != b.Cmc {
+				return a.Cmc < b.Cmc
+			}
+			return byName(a, b)
+		}
+	}
+	return nil
+}
+
+// Sorts the cards in the order (stable, so cards that are equal keep the insertion order)
+func sortCards(cards []Card, order DeckOrder) {
+	less := cardLess(order)
+	if less == nil {
+		return
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		return less(cards[i], cards[j])
+	})
+}
+
+// Returns the cards of the zone in the order of the deck
+func (d Deck) orderedCards(zone Zone) []Card {
+	result := d.getZone(zone).uniqueCards()
+	sortCards(result, d.order)
+	return result
+}
+
+// Returns the entries of the zone in the order of the deck (the finishes of a card keep their insertion order)
+func (d Deck) orderedEntries(zone Zone) []zoneEntry {
+	result := append([]zoneEntry{}, d.getZone(zone).entries...)
+	if less := cardLess(d.order); less != nil {
+		sort.SliceStable(result, func(i, j int) bool {
+			return less(result[i].card, result[j].card)
+		})
+	}
+	return result
+}
@@ -0,0 +1,288 @@
+package mtgsdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var (
+	// The aliases of the query keys
+	queryKeyAliases = map[string]string{
+		"name":      nameQueryKey,
+		"t":         typeQueryKey,
+		"type":      typeQueryKey,
+		"o":         oracleQueryKey,
+		"oracle":    oracleQueryKey,
+		"fo":        oracleQueryKey,
+		"c":         colorQueryKey,
+		"color":     colorQueryKey,
+		"id":        identityQueryKey,
+		"identity":  identityQueryKey,
+		"ci":        identityQueryKey,
+		"cmc":       cmcQueryKey,
+		"mv":        cmcQueryKey,
+		"manavalue": cmcQueryKey,
+		"r":         rarityQueryKey,
+		"rarity":    rarityQueryKey,
+		"pow":       powerQueryKey,
+		"power":     powerQueryKey,
+		"tou":       toughnessQueryKey,
+		"toughness": toughnessQueryKey,
+		"kw":        keywordQueryKey,
+		"keyword":   keywordQueryKey,
+		"s":         setQueryKey,
+		"e":         setQueryKey,
+		"set":       setQueryKey,
+		"edition":   setQueryKey,
+		"is":        isQueryKey,
+		"oracleid":  oracleIDQueryKey,
+		"unique":    uniqueQueryKey,
+	}
+
+	// The operators in the order they are matched
+	queryOperators = []Comparison{Gte, Lte, Neq, Gt, Lt, Eq, Has}
+)
+
+// A parser of scryfall search syntax
+type queryParser struct {
+	input string // the query
+	pos   int    // the current position
+}
+
+// Parses the scryfall search syntax to a query node
+//
+// Supports keyword terms (t:, o:, c:, id:, cmc, mv, r:, pow, tou, kw:, s:, is:, oracleid:, unique:), bare and quoted names,
+// exact names (!"name"), parentheses, and, or, not and negation with -
+func ParseQuery(query string) (QueryNode, error) {
+	p := &queryParser{input: query}
+	result, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("mtgsdk - unexpected %q at position %d in query %q", p.input[p.pos], p.pos, query)
+	}
+	return result, nil
+}
+
+// Parses a sequence of terms separated by or
+func (p *queryParser) parseOr() (QueryNode, error) {
+	result := orNode{}
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, node)
+		if !p.acceptWord("or") {
+			break
+		}
+	}
+	if len(result) == 1 {
+		return result[0], nil
+	}
+	return result, nil
+}
+
+// Parses a sequence of terms (optionally separated by and)
+func (p *queryParser) parseAnd() (QueryNode, error) {
+	result := andNode{}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) || p.input[p.pos] == ')' || p.peekWord("or") {
+			break
+		}
+		if p.acceptWord("and") {
+			continue
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, node)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("mtgsdk - expected a term at position %d in query %q", p.pos, p.input)
+	}
+	if len(result) == 1 {
+		return result[0], nil
+	}
+	return result, nil
+}
+
+// Parses a negated or a plain term
+func (p *queryParser) parseUnary() (QueryNode, error) {
+	p.skipSpaces()
+	if p.acceptWord("not") {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(node), nil
+	}
+	if p.pos < len(p.input) && p.input[p.pos] == '-' {
+		p.pos++
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(node), nil
+	}
+	return p.parsePrimary()
+}
+
+// Parses a term or a group in parentheses
+func (p *queryParser) parsePrimary() (QueryNode, error) {
+	if p.pos >= len(p.input) {
+		return nil, fmt.Errorf("mtgsdk - unexpected end of query %q", p.input)
+	}
+	if p.input[p.pos] == '(' {
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, fmt.Errorf("mtgsdk - missing closing parenthesis in query %q", p.input)
+		}
+		p.pos++
+		return node, nil
+	}
+	if p.input[p.pos] == '!' {
+		p.pos++
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return ExactName(value), nil
+	}
+	if p.input[p.pos] == '"' {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return Name(value), nil
+	}
+	// read the key (or the bare word)
+	start := p.pos
+	for p.pos < len(p.input) && (unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos]))) {
+		p.pos++
+	}
+	key := strings.ToLower(p.input[start:p.pos])
+	for _, op := range queryOperators {
+		if key != "" && strings.HasPrefix(p.input[p.pos:], string(op)) {
+			canonical, known := queryKeyAliases[key]
+			if !known {
+				return nil, fmt.Errorf("mtgsdk - unknown keyword %s in query %q", key, p.input)
+			}
+			p.pos += len(op)
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			return Field(canonical, op, value), nil
+		}
+	}
+	// not a keyword, read the rest of the bare word
+	p.pos = start
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return Name(value), nil
+}
+
+// Parses a quoted phrase or a word
+func (p *queryParser) parseValue() (string, error) {
+	if p.pos < len(p.input) && p.input[p.pos] == '"' {
+		end := strings.IndexByte(p.input[p.pos+1:], '"')
+		if end == -1 {
+			return "", fmt.Errorf("mtgsdk - missing closing quote in query %q", p.input)
+		}
+		value := p.input[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return value, nil
+	}
+	start := p.pos
+	for p.pos < len(p.input) && !unicode.IsSpace(rune(p.input[p.pos])) && p.input[p.pos] != ')' && p.input[p.pos] != '(' {
+		p.pos++
+	}
+	if start == p.pos {
+		return "", fmt.Errorf("mtgsdk - expected a value at position %d in query %q", p.pos, p.input)
+	}
+	return p.input[start:p.pos], nil
+}
+
+// Skips the whitespace
+func (p *queryParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// Returns true if the next word is the specified one (case insensitive)
+func (p *queryParser) peekWord(word string) bool {
+	p.skipSpaces()
+	end := p.pos + len(word)
+	if end > len(p.input) || !strings.EqualFold(p.input[p.pos:end], word) {
+		return false
+	}
+	return end == len(p.input) || unicode.IsSpace(rune(p.input[end])) || p.input[end] == '(' || p.input[end] == ')'
+}
+
+// Skips the next word if it's the specified one
+//
+// Returns true if skipped the word
+func (p *queryParser) acceptWord(word string) bool {
+	if !p.peekWord(word) {
+		return false
+	}
+	p.pos += len(word)
+	return true
+}
+
+// A query node that keeps the original query text
+type rawQueryNode struct {
+	query  string    // the original query
+	parsed QueryNode // the parsed query (nil if failed to parse)
+	err    error     // the parse error
+}
+
+// Returns a query node that is sent to scryfall as is and is matched offline by parsing it
+//
+// If the query can't be parsed, it never matches offline
+func RawQuery(query string) QueryNode {
+	parsed, err := ParseQuery(query)
+	return rawQueryNode{query: query, parsed: parsed, err: err}
+}
+
+func (n rawQueryNode) String() string {
+	return n.query
+}
+
+func (n rawQueryNode) Match(card Card) bool {
+	if n.parsed == nil {
+		return false
+	}
+	return n.parsed.Match(card)
+}
+
+// Searches the cards with scryfall search syntax
+//
+// If offline is true (or can't connect to scryfall), evaluates the query against the local cards
+func (c *Client) Search(query string, offline bool) ([]Card, error) {
+	return c.SearchContext(context.Background(), query, offline)
+}
+
+// Same as Search, but uses the context for the network requests
+func (c *Client) SearchContext(ctx context.Context, query string, offline bool) ([]Card, error) {
+	q := RawQuery(query).(rawQueryNode)
+	if offline && q.err != nil {
+		return nil, q.err
+	}
+	return c.GetCardsQueryContext(ctx, q, offline)
+}
@@ -0,0 +1,23 @@
+package mtgsdk
+
+import "testing"
+
+func TestParseQueryColorless(t *testing.T) {
+	for query, want := range map[string][]string{
+		"c:c":         {"colorless"},
+		"c:colorless": {"colorless"},
+		"color>=c":    {"colorless"},
+		"c=c":         {"colorless"},
+		"c!=c":        {"red", "izzet"},
+		"-c:c":        {"red", "izzet"},
+		"id:c":        {"colorless"},
+		"c:r":         {"red", "izzet"},
+		"c:ur or c:c": {"colorless", "izzet"},
+	} {
+		node, err := ParseQuery(query)
+		if err != nil {
+			t.Fatalf("can't parse %q: %v", query, err)
+		}
+		checkColorMatches(t, node, want...)
+	}
+}
@@ -0,0 +1,321 @@
+package mtgsdk
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A currency of card prices (used in scryfall api)
+type Currency string
+
+const (
+	CurrencyUSD Currency = "usd" // US dollars (TCGplayer)
+	CurrencyEUR Currency = "eur" // Euros (Cardmarket)
+	CurrencyTix Currency = "tix" // MTGO event tickets (Cardhoarder)
+)
+
+// A finish of a card printing (used in scryfall api)
+type Finish string
+
+const (
+	FinishNonfoil Finish = "nonfoil"
+	FinishFoil    Finish = "foil"
+	FinishEtched  Finish = "etched"
+)
+
+// The prices of a card (empty if the price is unknown)
+type Prices struct {
+	USD       string `json:"usd"`        // The price in US dollars
+	USDFoil   string `json:"usd_foil"`   // The price of the foil in US dollars
+	USDEtched string `json:"usd_etched"` // The price of the etched foil in US dollars
+	EUR       string `json:"eur"`        // The price in euros
+	EURFoil   string `json:"eur_foil"`   // The price of the foil in euros
+	EUREtched string `json:"eur_etched"` // The price of the etched foil in euros
+	Tix       string `json:"tix"`        // The price in MTGO event tickets
+}
+
+// URLs of the online stores that sell the card
+type PurchaseURIs struct {
+	TCGPlayer   string `json:"tcgplayer"`
+	Cardmarket  string `json:"cardmarket"`
+	Cardhoarder string `json:"cardhoarder"`
+}
+
+// Returns the raw price of the finish in the currency
+func (p Prices) get(currency Currency, finish Finish) string {
+	switch currency {
+	case CurrencyUSD:
+		switch finish {
+		case FinishFoil:
+			return p.USDFoil
+		case FinishEtched:
+			return p.USDEtched
+		}
+		return p.USD
+	case CurrencyEUR:
+		switch finish {
+		case FinishFoil:
+			return p.EURFoil
+		case FinishEtched:
+			return p.EUREtched
+		}
+		return p.EUR
+	case CurrencyTix:
+		// mtgo doesn't have foil prices
+		return p.Tix
+	}
+	return ""
+}
+
+// Returns the price of the card with the finish in the currency
+//
+// Returns false if the price is unknown
+func (c Card) Price(currency Currency, finish Finish) (float64, bool) {
+	raw := c.Prices.get(currency, finish)
+	if raw == "" {
+		return 0, false
+	}
+	result, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false
+	}
+	return result, true
+}
+
+// The price of a deck
+//
+// The prices are keyed by the deck entry (see DeckEntry.PriceKey), so the foil and nonfoil copies of a card are priced separately
+type DeckPrice struct {
+	Currency  Currency           // The currency of the prices
+	Finish    Finish             // The finish of the priced cards (empty if the finishes of the deck were used)
+	Total     float64            // The total price of the deck
+	Cards     map[string]float64 // The prices of all the copies of the entries (entry.PriceKey() -- price)
+	Printings map[string]Card    // The printings that were priced (entry.PriceKey() -- card)
+	Missing   []DeckEntry        // The entries without a price (sorted by name)
+}
+
+// Returns the key of the entry in DeckPrice (the id of the card and the finish of the copies)
+func (e DeckEntry) PriceKey() string {
+	return e.Card.ID + ":" + string(e.Finish)
+}
+
+// Adds the price of the copies of the entry, using the printing with the finish
+func (p *DeckPrice) add(entry DeckEntry, printing Card, finish Finish) {
+	price, has := printing.Price(p.Currency, finish)
+	if !has {
+		p.Missing = append(p.Missing, entry)
+		return
+	}
+	key := entry.PriceKey()
+	p.Cards[key] += price * float64(entry.Amount)
+	p.Printings[key] = printing
+	p.Total += price * float64(entry.Amount)
+}
+
+// Sorts the missing entries by name
+func (p *DeckPrice) sortMissing() {
+	sort.SliceStable(p.Missing, func(i, j int) bool {
+		return p.Missing[i].Card.Name < p.Missing[j].Card.Name
+	})
+}
+
+// Creates an empty deck price
+func newDeckPrice(currency Currency, finish Finish) *DeckPrice {
+	return &DeckPrice{
+		Currency:  currency,
+		Finish:    finish,
+		Cards:     map[string]float64{},
+		Printings: map[string]Card{},
+		Missing:   []DeckEntry{},
+	}
+}
+
+// The zones of the deck that are priced
+var pricedZones = []Zone{ZoneCommander, ZoneCompanion, ZoneMain, ZoneSideboard}
+
+const (
+	priceBatchSize = 15 // the maximum amount of cards in a single printings search (keeps the query under the length limit of scryfall)
+)
+
+// Returns the total price of the exact printings of the deck (every zone except the maybeboard)
+//
+// If finish is empty, uses the finish of every card in the deck. Entries without a price in the currency are listed in DeckPrice.Missing.
+// There is no option to price the cheapest printings here: finding them needs the card store or scryfall, which a deck doesn't have,
+// so the cheapest total is returned by Client.CheapestDeckPrice instead (with the same DeckPrice keys)
+func (d Deck) Price(currency Currency, finish Finish) (*DeckPrice, error) {
+	result := newDeckPrice(currency, finish)
+	for _, zone := range pricedZones {
+		for _, entry := range d.ZoneEntries(zone) {
+			result.add(entry, entry.Card, entry.priceFinish(finish))
+		}
+	}
+	result.sortMissing()
+	return result, nil
+}
+
+// Returns the total price of the deck, using the cheapest printing of every card
+//
+// The prices are keyed by the entries of the deck, and DeckPrice.Printings holds the printings that replaced them. If finish is empty, uses the finish of every card in the deck. If offline is true (or can't connect to scryfall), looks up the printings in the local cards
+func (c *Client) CheapestDeckPrice(deck Deck, currency Currency, finish Finish, offline bool) (*DeckPrice, error) {
+	return c.CheapestDeckPriceContext(context.Background(), deck, currency, finish, offline)
+}
+
+// Same as CheapestDeckPrice, but uses the context for the network requests
+//
+// The printings are searched in batches of priceBatchSize cards instead of one search per card
+func (c *Client) CheapestDeckPriceContext(ctx context.Context, deck Deck, currency Currency, finish Finish, offline bool) (*DeckPrice, error) {
+	entries := []DeckEntry{}
+	for _, zone := range pricedZones {
+		entries = append(entries, deck.ZoneEntries(zone)...)
+	}
+	cards := make([]Card, len(entries))
+	for i, entry := range entries {
+		cards[i] = entry.Card
+	}
+	printings, err := c.getAllPrintings(ctx, cards, offline)
+	if err != nil {
+		return nil, err
+	}
+	result := newDeckPrice(currency, finish)
+	for _, entry := range entries {
+		addCheapestPrinting(result, entry, entry.priceFinish(finish), printings[printingsKey(entry.Card)])
+	}
+	result.sortMissing()
+	return result, nil
+}
+
+// Adds the price of the entry, using the cheapest of the printings of its card
+func addCheapestPrinting(result *DeckPrice, entry DeckEntry, finish Finish, printings []Card) {
+	cheapest := entry.Card
+	cheapestPrice, found := cheapest.Price(result.Currency, finish)
+	for _, printing := range printings {
+		price, has := printing.Price(result.Currency, finish)
+		if has && (!found || price < cheapestPrice) {
+			cheapest = printing
+			cheapestPrice = price
+			found = true
+		}
+	}
+	result.add(entry, cheapest, finish)
+}
+
+// Returns the key that groups the printings of the card (the oracle id, or the name for cards without one, like reversible cards)
+func printingsKey(card Card) string {
+	if card.OracleID != "" {
+		return card.OracleID
+	}
+	return strings.ToLower(card.Name)
+}
+
+// Returns the query node that matches all the printings of the card
+func printingsQuery(card Card) QueryNode {
+	if card.OracleID != "" {
+		return Field(oracleIDQueryKey, Has, card.OracleID)
+	}
+	return ExactName(card.Name)
+}
+
+// Returns the printings of all the cards (printingsKey(card) -- printings)
+//
+// Searches scryfall for priceBatchSize cards at a time. If offline is true (or can't connect to scryfall), looks up the printings in the local cards
+func (c *Client) getAllPrintings(ctx context.Context, cards []Card, offline bool) (map[string][]Card, error) {
+	result := map[string][]Card{}
+	queries := []QueryNode{}
+	for _, card := range cards {
+		key := printingsKey(card)
+		if _, has := result[key]; has {
+			continue
+		}
+		result[key] = []Card{}
+		queries = append(queries, printingsQuery(card))
+	}
+	if offline {
+		return c.getAllPrintingsOffline(cards)
+	}
+	for start := 0; start < len(queries); start += priceBatchSize {
+		end := start + priceBatchSize
+		if end > len(queries) {
+			end = len(queries)
+		}
+		it := c.IterQueryContext(ctx, And(Or(queries[start:end]...), Field(uniqueQueryKey, Has, "prints")), 0)
+		for it.Next() {
+			key := printingsKey(it.Card())
+			result[key] = append(result[key], it.Card())
+		}
+		var dnsError *net.DNSError
+		if errors.As(it.Err(), &dnsError) {
+			log.Println("mtgsdk - failed to connect to host, looking up printings in the card store")
+			return c.getAllPrintingsOffline(cards)
+		}
+		if it.Err() != nil && !errors.Is(it.Err(), ErrNotFound) {
+			return nil, it.Err()
+		}
+	}
+	return result, nil
+}
+
+// Returns the printings of all the cards from the card store (printingsKey(card) -- printings)
+func (c *Client) getAllPrintingsOffline(cards []Card) (map[string][]Card, error) {
+	result := map[string][]Card{}
+	for _, card := range cards {
+		key := printingsKey(card)
+		if _, has := result[key]; has {
+			continue
+		}
+		printings, err := c.getPrintingsOffline(card)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = printings
+	}
+	return result, nil
+}
+
+// Returns the finish the copies are priced with (the finish of the entry if finish is empty)
+func (e DeckEntry) priceFinish(finish Finish) Finish {
+	if finish != "" {
+		return finish
+	}
+	return e.Finish
+}
+
+// Returns all the printings of the card
+//
+// If offline is true (or can't connect to scryfall), looks up the printings in the local cards
+func (c *Client) GetPrintings(card Card, offline bool) ([]Card, error) {
+	return c.GetPrintingsContext(context.Background(), card, offline)
+}
+
+// Same as GetPrintings, but uses the context for the network requests
+func (c *Client) GetPrintingsContext(ctx context.Context, card Card, offline bool) ([]Card, error) {
+	if offline || card.PrintsSearchURI == "" {
+		return c.getPrintingsOffline(card)
+	}
+	it := &CardIterator{client: c, ctx: ctx, nextURL: card.PrintsSearchURI}
+	result := []Card{}
+	for it.Next() {
+		result = append(result, it.Card())
+	}
+	var dnsError *net.DNSError
+	if errors.As(it.Err(), &dnsError) {
+		log.Println("mtgsdk - failed to connect to host, looking up printings in the card store")
+		return c.getPrintingsOffline(card)
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	return result, nil
+}
+
+// Returns the printings of the card from the card store
+func (c *Client) getPrintingsOffline(card Card) ([]Card, error) {
+	if card.OracleID == "" {
+		return c.store.FindByName(card.Name)
+	}
+	return c.store.FindByOracleID(card.OracleID)
+}
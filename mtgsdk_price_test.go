@@ -0,0 +1,78 @@
+package mtgsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCheapestDeckPriceBatches(t *testing.T) {
+	oracleIDRe := regexp.MustCompile(`oracleid:"?([\w-]+)`)
+	var searches int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&searches, 1)
+		page := cardPage{Data: []Card{}}
+		for _, match := range oracleIDRe.FindAllStringSubmatch(r.URL.Query().Get("q"), -1) {
+			page.Data = append(page.Data, Card{ID: match[1] + "-cheap", OracleID: match[1], Name: "Card " + match[1], Prices: Prices{USD: "1.00"}})
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+
+	deck := CreateDeck("test")
+	cardCount := priceBatchSize + 1
+	for i := 0; i < cardCount; i++ {
+		oracleID := fmt.Sprintf("oracle-%d", i)
+		deck.AddCard(&Card{ID: oracleID + "-expensive", OracleID: oracleID, Name: "Card " + oracleID, Prices: Prices{USD: "5.00"}}, 2)
+	}
+	price, err := client.CheapestDeckPrice(*deck, CurrencyUSD, FinishNonfoil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if searches != 2 {
+		t.Fatalf("expected 2 searches for %d cards, got %d", cardCount, searches)
+	}
+	if expected := float64(cardCount * 2); price.Total != expected {
+		t.Fatalf("expected the total %v, got %v", expected, price.Total)
+	}
+	if len(price.Missing) != 0 {
+		t.Fatalf("expected no missing prices, got %v", price.Missing)
+	}
+}
+
+func TestDeckPriceKeysEntries(t *testing.T) {
+	solRing := Card{ID: "sol-ring", Name: "Sol Ring", Prices: Prices{USD: "1.50", USDFoil: "4.00"}}
+	forest := Card{ID: "forest", Name: "Forest", Prices: Prices{USD: "0.10"}}
+	deck := CreateDeck("test")
+	deck.AddToZoneWithFinish(ZoneMain, &solRing, 1, FinishNonfoil)
+	deck.AddToZoneWithFinish(ZoneMain, &solRing, 1, FinishFoil)
+	deck.AddToZoneWithFinish(ZoneMain, &forest, 10, FinishNonfoil)
+	deck.AddToZoneWithFinish(ZoneMain, &forest, 1, FinishEtched)
+
+	price, err := deck.Price(CurrencyUSD, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]float64{"sol-ring:nonfoil": 1.5, "sol-ring:foil": 4, "forest:nonfoil": 1}
+	if !reflect.DeepEqual(price.Cards, expected) {
+		t.Fatalf("expected the prices %v, got %v", expected, price.Cards)
+	}
+	if price.Total != 6.5 {
+		t.Fatalf("expected the total 6.5, got %v", price.Total)
+	}
+	if len(price.Missing) != 1 || price.Missing[0].PriceKey() != "forest:etched" {
+		t.Fatalf("expected the etched forest to be missing, got %v", price.Missing)
+	}
+
+	// the finish override still keeps the entries apart
+	price, err = deck.Price(CurrencyUSD, FinishFoil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Cards["sol-ring:nonfoil"] != 4 || price.Cards["sol-ring:foil"] != 4 {
+		t.Fatalf("expected both sol ring entries priced as foil, got %v", price.Cards)
+	}
+}
@@ -0,0 +1,46 @@
+package mtgsdk
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	// The markers of the finishes in deck lines (finish -- marker)
+	finishMarkers = map[Finish]string{
+		FinishFoil:   "*F*",
+		FinishEtched: "*E*",
+	}
+)
+
+// A printing of a card in the deck
+type DeckEntry struct {
+	Card   Card   // The card (its set and collector number identify the printing)
+	Amount int    // The amount of copies
+	Finish Finish // The finish of the copies
+}
+
+// Returns the set code of the printing
+func (e DeckEntry) SetCode() string {
+	return e.Card.Set
+}
+
+// Returns the collector number of the printing
+func (e DeckEntry) CollectorNumber() string {
+	return e.Card.CollectorNumber
+}
+
+// Returns the entry as a deck line (1 Sol Ring (C21) 263 *F*)
+func (e DeckEntry) String() string {
+	result := fmt.Sprintf("%d %s", e.Amount, e.Card.Name)
+	if e.Card.Set != "" {
+		result += fmt.Sprintf(" (%s)", strings.ToUpper(e.Card.Set))
+		if e.Card.CollectorNumber != "" {
+			result += " " + e.Card.CollectorNumber
+		}
+	}
+	if marker, has := finishMarkers[e.Finish]; has {
+		result += " " + marker
+	}
+	return result
+}
@@ -0,0 +1,686 @@
+package mtgsdk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A comparison operator of a query
+type Comparison string
+
+const (
+	Has Comparison = ":"  // The default scryfall operator (contains for text, equals for numbers)
+	Eq  Comparison = "="  // Equal to
+	Neq Comparison = "!=" // Not equal to
+	Lt  Comparison = "<"  // Less than
+	Lte Comparison = "<=" // Less than or equal to
+	Gt  Comparison = ">"  // Greater than
+	Gte Comparison = ">=" // Greater than or equal to
+)
+
+// The canonical query keys
+const (
+	nameQueryKey      = "name"
+	exactNameQueryKey = "!"
+	typeQueryKey      = "t"
+	oracleQueryKey    = "o"
+	colorQueryKey     = "c"
+	identityQueryKey  = "id"
+	cmcQueryKey       = "cmc"
+	rarityQueryKey    = "r"
+	powerQueryKey     = "pow"
+	toughnessQueryKey = "tou"
+	keywordQueryKey   = "kw"
+	setQueryKey       = "set"
+	isQueryKey        = "is"
+	oracleIDQueryKey  = "oracleid"
+	uniqueQueryKey    = "unique"
+)
+
+var (
+	// The color symbols of the color names
+	colorNames = map[string]string{
+		"white":     "w",
+		"blue":      "u",
+		"black":     "b",
+		"red":       "r",
+		"green":     "g",
+		"colorless": "c",
+		"azorius":   "wu",
+		"dimir":     "ub",
+		"rakdos":    "br",
+		"gruul":     "rg",
+		"selesnya":  "gw",
+		"orzhov":    "wb",
+		"izzet":     "ur",
+		"golgari":   "bg",
+		"boros":     "rw",
+		"simic":     "gu",
+		"esper":     "wub",
+		"grixis":    "ubr",
+		"jund":      "brg",
+		"naya":      "rgw",
+		"bant":      "gwu",
+		"abzan":     "wbg",
+		"jeskai":    "urw",
+		"sultai":    "bgu",
+		"mardu":     "rwb",
+		"temur":     "gur",
+	}
+
+	// The order of the rarities (used in rarity comparisons)
+	rarityOrder = map[string]int{
+		"common":   0,
+		"uncommon": 1,
+		"rare":     2,
+		"special":  3,
+		"mythic":   4,
+		"bonus":    5,
+	}
+)
+
+// A node of a scryfall query
+//
+// Can be serialized to scryfall syntax and matched against a card offline
+type QueryNode interface {
+	String() string       // Returns the node in scryfall syntax
+	Match(card Card) bool // Returns true if the card matches the node
+}
+
+// A query node that compares a card field with a value
+type fieldNode struct {
+	key   string     // the canonical key
+	op    Comparison // the operator
+	value string     // the value
+}
+
+// Returns a query node that compares the field with the value
+//
+// The key can be any scryfall keyword, the ones not known by mtgsdk never match offline.
+// Aliases (c and color, t and type, o and oracle, ...) are mapped to the same keys as in ParseQuery
+func Field(key string, op Comparison, value string) QueryNode {
+	if canonical, known := queryKeyAliases[strings.ToLower(key)]; known {
+		key = canonical
+	}
+	return fieldNode{key: key, op: op, value: value}
+}
+
+// Returns a query node for cards which names contain the value
+func Name(name string) QueryNode {
+	// bare words are matched against card names by scryfall
+	return fieldNode{key: "", op: Has, value: name}
+}
+
+// Returns a query node for cards with the exact name
+func ExactName(name string) QueryNode {
+	return fieldNode{key: exactNameQueryKey, op: Has, value: name}
+}
+
+// Returns a query node for cards which type lines contain the value
+func Type(typeLine string) QueryNode {
+	return fieldNode{key: typeQueryKey, op: Has, value: typeLine}
+}
+
+// Returns a query node for cards which oracle texts contain the value
+func Oracle(text string) QueryNode {
+	return fieldNode{key: oracleQueryKey, op: Has, value: text}
+}
+
+// Returns a query node for cards from the set with the specified code
+func InSet(setCode string) QueryNode {
+	return fieldNode{key: setQueryKey, op: Has, value: setCode}
+}
+
+// Returns a query node for cards with the specified rarity
+func Rarity(op Comparison, rarity string) QueryNode {
+	return fieldNode{key: rarityQueryKey, op: op, value: rarity}
+}
+
+// Returns a query node for cards which colors match the filter
+func Color(filter ColorFilter) QueryNode {
+	return fieldNode{key: colorQueryKey, op: filter.op, value: filter.colors}
+}
+
+// Returns a query node for cards which color identities match the filter
+func Identity(filter ColorFilter) QueryNode {
+	return fieldNode{key: identityQueryKey, op: filter.op, value: filter.colors}
+}
+
+// Returns a query node that compares the mana value of cards
+func CMC(op Comparison, value float64) QueryNode {
+	return fieldNode{key: cmcQueryKey, op: op, value: strconv.FormatFloat(value, 'f', -1, 64)}
+}
+
+// Returns a query node that compares the power of cards
+func Power(op Comparison, value float64) QueryNode {
+	return fieldNode{key: powerQueryKey, op: op, value: strconv.FormatFloat(value, 'f', -1, 64)}
+}
+
+// Returns a query node that compares the toughness of cards
+func Toughness(op Comparison, value float64) QueryNode {
+	return fieldNode{key: toughnessQueryKey, op: op, value: strconv.FormatFloat(value, 'f', -1, 64)}
+}
+
+// Returns a query node for cards with the keyword
+func Keyword(keyword string) QueryNode {
+	return fieldNode{key: keywordQueryKey, op: Has, value: keyword}
+}
+
+// Returns a query node for cards with the property (for example, commander or permanent)
+func Is(property string) QueryNode {
+	return fieldNode{key: isQueryKey, op: Has, value: property}
+}
+
+func (n fieldNode) String() string {
+	value := quoteQueryValue(n.value)
+	switch n.key {
+	case "":
+		return value
+	case exactNameQueryKey:
+		return "!" + value
+	}
+	return n.key + string(n.op) + value
+}
+
+func (n fieldNode) Match(card Card) bool {
+	switch n.key {
+	case "", nameQueryKey:
+		return matchText(card.Name, n.op, n.value)
+	case exactNameQueryKey:
+		return strings.EqualFold(card.Name, n.value)
+	case typeQueryKey:
+		return matchText(card.TypeLine, n.op, n.value)
+	case oracleQueryKey:
+		return matchText(card.FullOracleText(), n.op, strings.ReplaceAll(n.value, "~", card.FrontFace().Name))
+	case colorQueryKey:
+		return matchColors(card.AllColors(), n.op, n.value, Gte)
+	case identityQueryKey:
+		return matchColors(card.ColorIdentity, n.op, n.value, Lte)
+	case cmcQueryKey, powerQueryKey, toughnessQueryKey:
+		return matchNumber(numberField(card, n.key), n.op, numberValue(card, n.value))
+	case rarityQueryKey:
+		return matchRarity(card.Rarity, n.op, n.value)
+	case keywordQueryKey:
+		for _, keyword := range card.Keywords {
+			if strings.EqualFold(keyword, n.value) {
+				return n.op != Neq
+			}
+		}
+		return n.op == Neq
+	case setQueryKey:
+		return strings.EqualFold(card.Set, n.value) != (n.op == Neq)
+	case isQueryKey:
+		return matchIs(card, n.value) != (n.op == Neq)
+	case oracleIDQueryKey:
+		return strings.EqualFold(card.OracleID, n.value) != (n.op == Neq)
+	case uniqueQueryKey:
+		// only changes how scryfall groups the results
+		return true
+	}
+	return false
+}
+
+// A query node that matches cards matching all of its children
+type andNode []QueryNode
+
+// Returns a query node for cards that match all the nodes
+func And(nodes ...QueryNode) QueryNode {
+	return andNode(nodes)
+}
+
+func (n andNode) String() string {
+	parts := make([]string, 0, len(n))
+	for _, node := range n {
+		s := node.String()
+		if s == "" {
+			continue
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (n andNode) Match(card Card) bool {
+	for _, node := range n {
+		if !node.Match(card) {
+			return false
+		}
+	}
+	return true
+}
+
+// A query node that matches cards matching any of its children
+type orNode []QueryNode
+
+// Returns a query node for cards that match any of the nodes
+func Or(nodes ...QueryNode) QueryNode {
+	return orNode(nodes)
+}
+
+func (n orNode) String() string {
+	parts := make([]string, 0, len(n))
+	for _, node := range n {
+		s := groupQuery(node)
+		if s == "" {
+			continue
+		}
+		parts = append(parts, s)
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return "(" + strings.Join(parts, " or ") + ")"
+}
+
+func (n orNode) Match(card Card) bool {
+	for _, node := range n {
+		if node.Match(card) {
+			return true
+		}
+	}
+	return false
+}
+
+// A query node that matches cards not matching its child
+type notNode struct {
+	node QueryNode
+}
+
+// Returns a query node for cards that don't match the node
+func Not(node QueryNode) QueryNode {
+	return notNode{node: node}
+}
+
+func (n notNode) String() string {
+	return "-" + groupQuery(n.node)
+}
+
+func (n notNode) Match(card Card) bool {
+	return !n.node.Match(card)
+}
+
+// A filter for card colors
+type ColorFilter struct {
+	op     Comparison // the operator
+	colors string     // the colors (for example, wu)
+}
+
+// Matches the cards that have exactly the colors ("C" for colorless)
+func ColorsExactly(colors string) ColorFilter {
+	return ColorFilter{op: Eq, colors: strings.ToLower(colors)}
+}
+
+// Matches the cards that have at most the colors
+func ColorsAtMost(colors string) ColorFilter {
+	return ColorFilter{op: Lte, colors: strings.ToLower(colors)}
+}
+
+// Matches the cards that have at least the colors
+func ColorsAtLeast(colors string) ColorFilter {
+	return ColorFilter{op: Gte, colors: strings.ToLower(colors)}
+}
+
+// A builder of scryfall queries
+//
+// Matches cards that match all of the added nodes
+type QueryBuilder struct {
+	nodes andNode // the added nodes
+}
+
+// Creates an empty query builder
+func Query() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// Adds the nodes to the query
+func (q *QueryBuilder) Where(nodes ...QueryNode) *QueryBuilder {
+	q.nodes = append(q.nodes, nodes...)
+	return q
+}
+
+// Adds a name filter to the query
+func (q *QueryBuilder) Name(name string) *QueryBuilder {
+	return q.Where(Name(name))
+}
+
+// Adds an exact name filter to the query
+func (q *QueryBuilder) ExactName(name string) *QueryBuilder {
+	return q.Where(ExactName(name))
+}
+
+// Adds a type line filter to the query
+func (q *QueryBuilder) Type(typeLine string) *QueryBuilder {
+	return q.Where(Type(typeLine))
+}
+
+// Adds an oracle text filter to the query
+func (q *QueryBuilder) Oracle(text string) *QueryBuilder {
+	return q.Where(Oracle(text))
+}
+
+// Adds a set filter to the query
+func (q *QueryBuilder) InSet(setCode string) *QueryBuilder {
+	return q.Where(InSet(setCode))
+}
+
+// Adds a rarity filter to the query
+func (q *QueryBuilder) Rarity(op Comparison, rarity string) *QueryBuilder {
+	return q.Where(Rarity(op, rarity))
+}
+
+// Adds a color filter to the query
+func (q *QueryBuilder) Color(filter ColorFilter) *QueryBuilder {
+	return q.Where(Color(filter))
+}
+
+// Adds a color identity filter to the query
+func (q *QueryBuilder) Identity(filter ColorFilter) *QueryBuilder {
+	return q.Where(Identity(filter))
+}
+
+// Adds a mana value filter to the query
+func (q *QueryBuilder) CMC(op Comparison, value float64) *QueryBuilder {
+	return q.Where(CMC(op, value))
+}
+
+// Adds a power filter to the query
+func (q *QueryBuilder) Power(op Comparison, value float64) *QueryBuilder {
+	return q.Where(Power(op, value))
+}
+
+// Adds a toughness filter to the query
+func (q *QueryBuilder) Toughness(op Comparison, value float64) *QueryBuilder {
+	return q.Where(Toughness(op, value))
+}
+
+// Adds a keyword filter to the query
+func (q *QueryBuilder) Keyword(keyword string) *QueryBuilder {
+	return q.Where(Keyword(keyword))
+}
+
+// Adds a property filter to the query
+func (q *QueryBuilder) Is(property string) *QueryBuilder {
+	return q.Where(Is(property))
+}
+
+// Adds a filter of the field to the query (see Field)
+func (q *QueryBuilder) Field(key string, op Comparison, value string) *QueryBuilder {
+	return q.Where(Field(key, op, value))
+}
+
+// Adds a negated node to the query
+func (q *QueryBuilder) Not(node QueryNode) *QueryBuilder {
+	return q.Where(Not(node))
+}
+
+// Adds a node that matches any of the nodes to the query
+func (q *QueryBuilder) Or(nodes ...QueryNode) *QueryBuilder {
+	return q.Where(Or(nodes...))
+}
+
+func (q *QueryBuilder) String() string {
+	return q.nodes.String()
+}
+
+func (q *QueryBuilder) Match(card Card) bool {
+	return q.nodes.Match(card)
+}
+
+// Converts the params to a query node
+//
+// The keys are sorted, so that the query is always the same for the same params
+func paramsToQuery(params map[string]string) QueryNode {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := andNode{}
+	for _, key := range keys {
+		value := params[key]
+		if value == "" {
+			continue
+		}
+		switch key {
+		case CardNameKey:
+			result = append(result, Name(value))
+		case SetNameKey:
+			result = append(result, InSet(value))
+		default:
+			result = append(result, Field(key, Has, value))
+		}
+	}
+	return result
+}
+
+// Returns the node as a single scryfall term, adding parentheses if needed
+func groupQuery(node QueryNode) string {
+	s := node.String()
+	size := 0
+	switch n := node.(type) {
+	case andNode:
+		size = len(n)
+	case *QueryBuilder:
+		size = len(n.nodes)
+	}
+	if size > 1 {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// Quotes the query value if it contains spaces or special characters
+func quoteQueryValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t\"():<>=!-") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// Matches the text field with the value (case insensitive)
+func matchText(field string, op Comparison, value string) bool {
+	field = strings.ToLower(field)
+	value = strings.ToLower(value)
+	switch op {
+	case Eq:
+		return field == value
+	case Neq:
+		return !strings.Contains(field, value)
+	}
+	return strings.Contains(field, value)
+}
+
+// Returns the number field of the card with the canonical key
+func numberField(card Card, key string) string {
+	switch key {
+	case cmcQueryKey:
+		return strconv.FormatFloat(card.Cmc, 'f', -1, 64)
+	case powerQueryKey:
+		return card.FrontFace().Power
+	case toughnessQueryKey:
+		return card.FrontFace().Toughness
+	}
+	return ""
+}
+
+// Returns the value of the number comparison
+//
+// The value can be the name of another number field (for example, pow>tou)
+func numberValue(card Card, value string) string {
+	switch key := queryKeyAliases[strings.ToLower(value)]; key {
+	case cmcQueryKey, powerQueryKey, toughnessQueryKey:
+		return numberField(card, key)
+	}
+	return value
+}
+
+// Compares the number field with the value
+//
+// Fields that are not numbers (for example, * power) never match
+func matchNumber(field string, op Comparison, value string) bool {
+	f, err := strconv.ParseFloat(field, 64)
+	if err != nil {
+		return false
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+	return compareFloats(f, op, v)
+}
+
+// Compares two numbers with the operator
+func compareFloats(a float64, op Comparison, b float64) bool {
+	switch op {
+	case Neq:
+		return a != b
+	case Lt:
+		return a < b
+	case Lte:
+		return a <= b
+	case Gt:
+		return a > b
+	case Gte:
+		return a >= b
+	}
+	return a == b
+}
+
+// Compares the rarity of the card with the value
+func matchRarity(rarity string, op Comparison, value string) bool {
+	r, has := rarityOrder[strings.ToLower(rarity)]
+	if !has {
+		return false
+	}
+	v, has := rarityOrder[strings.ToLower(value)]
+	if !has {
+		// allow the short forms of the rarities (c, u, r, m)
+		for name, order := range rarityOrder {
+			if strings.HasPrefix(name, strings.ToLower(value)) {
+				v, has = order, true
+				break
+			}
+		}
+		if !has {
+			return false
+		}
+	}
+	return compareFloats(float64(r), op, float64(v))
+}
+
+// Parses the colors of the value to a set of color symbols
+//
+// The value can be a combination of color symbols or a color name (for example, blue or azorius)
+func parseColors(value string) (map[string]bool, error) {
+	result := map[string]bool{}
+	if symbols, has := colorNames[strings.ToLower(value)]; has {
+		value = symbols
+	}
+	for _, r := range strings.ToUpper(value) {
+		switch r {
+		case 'W', 'U', 'B', 'R', 'G':
+			result[string(r)] = true
+		case 'C':
+			// colorless
+		default:
+			return nil, fmt.Errorf("mtgsdk - unknown color %c in %s", r, value)
+		}
+	}
+	return result, nil
+}
+
+// Compares the colors with the value
+//
+// hasOp is the operator used in place of the ":" operator
+func matchColors(colors []string, op Comparison, value string, hasOp Comparison) bool {
+	have := map[string]bool{}
+	for _, color := range colors {
+		have[color] = true
+	}
+	if v := strings.ToLower(value); v == "m" || v == "multicolor" {
+		return (len(have) > 1) != (op == Neq)
+	}
+	want, err := parseColors(value)
+	if err != nil {
+		return false
+	}
+	if op == Has {
+		op = hasOp
+	}
+	if len(want) == 0 && (op == Gte || op == Has) {
+		// every card has at least no colors, so colorless is compared as an exact match
+		op = Eq
+	}
+	subset := isColorSubset(have, want)
+	superset := isColorSubset(want, have)
+	switch op {
+	case Eq:
+		return subset && superset
+	case Neq:
+		return !(subset && superset)
+	case Lt:
+		return subset && !superset
+	case Lte:
+		return subset
+	case Gt:
+		return superset && !subset
+	}
+	return superset
+}
+
+// Returns true if all colors of a are in b
+func isColorSubset(a map[string]bool, b map[string]bool) bool {
+	for color := range a {
+		if !b[color] {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the card has the property
+func matchIs(card Card, property string) bool {
+	switch strings.ToLower(property) {
+	case "commander":
+		return (card.IsLegendary() && card.IsCreature()) || strings.Contains(card.FullOracleText(), "can be your commander")
+	case "permanent":
+		return card.IsPermanent()
+	case "spell":
+		return !card.IsLand()
+	case "historic":
+		return card.IsLegendary() || strings.Contains(card.TypeLine, "Artifact") || strings.Contains(card.TypeLine, "Saga")
+	case "split":
+		return card.Layout == LayoutSplit
+	case "flip":
+		return card.Layout == LayoutFlip
+	case "transform":
+		return card.Layout == LayoutTransform
+	case "mdfc":
+		return card.Layout == LayoutModalDFC
+	case "adventure":
+		return card.Layout == LayoutAdventure
+	case "dfc":
+		return card.IsDoubleFaced()
+	case "vanilla":
+		return card.IsCreature() && card.FullOracleText() == ""
+	case "creature":
+		return card.IsCreature()
+	case "land":
+		return card.IsLand()
+	case "legendary":
+		return card.IsLegendary()
+	case "basic":
+		return card.IsBasicLand()
+	case "ramp":
+		return card.IsRamp()
+	case "removal":
+		return card.IsRemoval()
+	case "boardwipe":
+		return card.IsBoardWipe()
+	case "draw":
+		return card.IsCardDraw()
+	}
+	return false
+}
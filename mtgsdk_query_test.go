@@ -0,0 +1,72 @@
+package mtgsdk
+
+import "testing"
+
+var (
+	// Cards of every color group used by the color tests
+	colorTestCards = map[string]Card{
+		"colorless": {Name: "Sol Ring", Colors: []string{}, ColorIdentity: []string{}},
+		"red":       {Name: "Lightning Bolt", Colors: []string{"R"}, ColorIdentity: []string{"R"}},
+		"izzet":     {Name: "Expansion // Explosion", Colors: []string{"U", "R"}, ColorIdentity: []string{"U", "R"}},
+	}
+)
+
+// Checks that the node matches exactly the named cards of colorTestCards
+func checkColorMatches(t *testing.T, node QueryNode, want ...string) {
+	t.Helper()
+	expected := map[string]bool{}
+	for _, name := range want {
+		expected[name] = true
+	}
+	for name, card := range colorTestCards {
+		if got := node.Match(card); got != expected[name] {
+			t.Errorf("%s: match of the %s card is %v, expected %v", node, name, got, expected[name])
+		}
+	}
+}
+
+func TestColorFilterColorless(t *testing.T) {
+	checkColorMatches(t, Color(ColorsAtLeast("C")), "colorless")
+	checkColorMatches(t, Color(ColorsExactly("C")), "colorless")
+	checkColorMatches(t, Color(ColorsAtMost("C")), "colorless")
+	checkColorMatches(t, Field(colorQueryKey, Has, "colorless"), "colorless")
+	checkColorMatches(t, Field(colorQueryKey, Neq, "c"), "red", "izzet")
+}
+
+func TestColorFilter(t *testing.T) {
+	checkColorMatches(t, Color(ColorsAtLeast("R")), "red", "izzet")
+	checkColorMatches(t, Color(ColorsExactly("R")), "red")
+	checkColorMatches(t, Color(ColorsAtMost("UR")), "colorless", "red", "izzet")
+	checkColorMatches(t, Field(colorQueryKey, Has, "izzet"), "izzet")
+	checkColorMatches(t, Field(colorQueryKey, Has, "m"), "izzet")
+}
+
+func TestFieldAliases(t *testing.T) {
+	card := Card{Name: "Lightning Bolt", TypeLine: "Instant", OracleText: "Lightning Bolt deals 3 damage to any target.", Colors: []string{"R"}}
+	for _, test := range []struct {
+		key   string // The key passed to Field
+		value string // The value passed to Field
+		query string // The expected query
+	}{
+		{"color", "r", "c:r"},
+		{"C", "r", "c:r"},
+		{"type", "instant", "t:instant"},
+		{"oracle", "damage", "o:damage"},
+		{"edition", "m10", "set:m10"},
+	} {
+		node := Query().Field(test.key, Has, test.value)
+		if node.String() != test.query {
+			t.Errorf("%s: expected the query %s, got %s", test.key, test.query, node.String())
+		}
+		if test.key != "edition" && !node.Match(card) {
+			t.Errorf("%s: expected %s to match the card offline", test.key, node)
+		}
+		parsed, err := ParseQuery(test.key + ":" + test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.String() != test.query {
+			t.Errorf("%s: expected ParseQuery to give the same query %s, got %s", test.key, test.query, parsed)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package mtgsdk
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A saved revision of a deck
+type DeckRevision struct {
+	Number int          `json:"number"` // The number of the revision (starting from 1)
+	Time   time.Time    `json:"time"`   // The time the revision was saved
+	Note   string       `json:"note"`   // The note of the revision
+	Deck   DeckDocument `json:"deck"`   // The document of the deck (without the card snapshots)
+}
+
+// The revisions of a deck stored in the decks folder
+type deckHistory struct {
+	Name      string          `json:"name"`      // The name of the deck
+	Cards     map[string]Card `json:"cards"`     // The map of the cards of all the revisions (card.id -- card)
+	Revisions []DeckRevision  `json:"revisions"` // The revisions (oldest first)
+}
+
+// Returns the deck of the revision of the history
+func (h deckHistory) deck(revision DeckRevision) (Deck, error) {
+	doc := revision.Deck
+	doc.Zones = map[Zone][]DeckDocumentEntry{}
+	for zone, entries := range revision.Deck.Zones {
+		withCards := make([]DeckDocumentEntry, len(entries))
+		for i, entry := range entries {
+			if card, has := h.Cards[entry.ID]; has {
+				entry.Card = &card
+			}
+			withCards[i] = entry
+		}
+		doc.Zones[zone] = withCards
+	}
+	return doc.Deck()
+}
+
+// The saved decks of a client, stored with their full revision history in the client data
+type DeckRepository struct {
+	adm dataManager // the data manager of the client
+	mu  *sync.Mutex // guards the deck history files
+}
+
+// Returns the deck repository of the client
+func (c *Client) Decks() DeckRepository {
+	return DeckRepository{adm: c.adm, mu: &c.decksMu}
+}
+
+// Creates the decks folder
+func (c *Client) createDecksFolder() error {
+	exists, err := c.adm.FileExists(decksFolder)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err = c.adm.CreateFolder(decksFolder)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the path of the history file of the deck (the name is hashed, so any name can be used)
+func deckHistoryFile(name string) string {
+	hash := sha1.Sum([]byte(name))
+	return path.Join(decksFolder, hex.EncodeToString(hash[:])+".json")
+}
+
+// Reads the history of the deck
+func (r DeckRepository) readHistory(name string) (deckHistory, error) {
+	file := deckHistoryFile(name)
+	exists, err := r.adm.FileExists(file)
+	if err != nil {
+		return deckHistory{}, err
+	}
+	if !exists {
+		return deckHistory{}, fmt.Errorf("mtgsdk - deck %s not found: %w", name, ErrNotFound)
+	}
+	data, err := r.adm.ReadFile(file)
+	if err != nil {
+		return deckHistory{}, err
+	}
+	var result deckHistory
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
+
+// Writes the history of the deck
+func (r DeckRepository) writeHistory(history deckHistory) error {
+	data, err := json.MarshalIndent(history, "", "\t")
+	if err != nil {
+		return err
+	}
+	return r.adm.WriteToFile(deckHistoryFile(history.Name), data)
+}
+
+// Reads the index of the saved decks (deck name -- history file)
+//
+// Builds the index from the history files if it doesn't exist (decks saved before the index was added)
+func (r DeckRepository) readIndex() (map[string]string, error) {
+	exists, err := r.adm.FileExists(decksIndexFile)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return r.buildIndex()
+	}
+	data, err := r.adm.ReadFile(decksIndexFile)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]string{}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("mtgsdk - can't read the deck index: %w", err)
+	}
+	return result, nil
+}
+
+// Builds the index of the saved decks from the history files and writes it
+func (r DeckRepository) buildIndex() (map[string]string, error) {
+	files, err := r.adm.ListFiles(decksFolder)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]string{}
+	for _, file := range files {
+		file = path.Join(decksFolder, file)
+		if file == decksIndexFile || path.Ext(file) != ".json" {
+			continue
+		}
+		data, err := r.adm.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var history deckHistory
+		err = json.Unmarshal(data, &history)
+		if err != nil {
+			return nil, fmt.Errorf("mtgsdk - can't read deck history %s: %w", file, err)
+		}
+		result[history.Name] = file
+	}
+	return result, r.writeIndex(result)
+}
+
+// Writes the index of the saved decks
+func (r DeckRepository) writeIndex(index map[string]string) error {
+	data, err := json.MarshalIndent(index, "", "\t")
+	if err != nil {
+		return err
+	}
+	return r.adm.WriteToFile(decksIndexFile, data)
+}
+
+// Adds the document as a new revision of the deck, returns the number of the revision
+func (r DeckRepository) addRevision(name string, doc DeckDocument, cards []Card, note string) (int, error) {
+	history, err := r.readHistory(name)
+	isNew := errors.Is(err, ErrNotFound)
+	if isNew {
+		history, err = deckHistory{Name: name, Cards: map[string]Card{}}, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	for _, card := range cards {
+		history.Cards[card.ID] = card
+	}
+	revision := DeckRevision{Number: len(history.Revisions) + 1, Time: time.Now(), Note: note, Deck: doc}
+	history.Revisions = append(history.Revisions, revision)
+	err = r.writeHistory(history)
+	if err != nil || !isNew {
+		return revision.Number, err
+	}
+	// the deck is new, so it's added to the index
+	index, err := r.readIndex()
+	if err != nil {
+		return 0, err
+	}
+	index[name] = deckHistoryFile(name)
+	return revision.Number, r.writeIndex(index)
+}
+
+// Saves the deck as a new revision with the note, returns the number of the revision
+//
+// The decks are identified by their names
+func (r DeckRepository) Save(deck Deck, note string) (int, error) {
+	if deck.Name == "" {
+		return 0, fmt.Errorf("mtgsdk - can't save a deck without a name")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.addRevision(deck.Name, deck.Document(false), deck.allCards(), note)
+}
+
+// Loads the latest revision of the deck
+func (r DeckRepository) Load(name string) (Deck, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	history, err := r.readHistory(name)
+	if err != nil {
+		return Deck{}, err
+	}
+	return history.deck(history.Revisions[len(history.Revisions)-1])
+}
+
+// Loads the revision of the deck
+func (r DeckRepository) LoadRevision(name string, revision int) (Deck, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	history, err := r.readHistory(name)
+	if err != nil {
+		return Deck{}, err
+	}
+	if revision <= 0 || revision > len(history.Revisions) {
+		return Deck{}, fmt.Errorf("mtgsdk - revision %d of deck %s not found: %w", revision, name, ErrNotFound)
+	}
+	return history.deck(history.Revisions[revision-1])
+}
+
+// Returns the names of the saved decks (in alphabetical order)
+func (r DeckRepository) List() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	index, err := r.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(index))
+	for name := range index {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// Returns the revisions of the deck (oldest first)
+//
+// The documents of the revisions don't have the card snapshots, use LoadRevision to get the full deck
+func (r DeckRepository) History(name string) ([]DeckRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	history, err := r.readHistory(name)
+	if err != nil {
+		return nil, err
+	}
+	return history.Revisions, nil
+}
+
+// Saves the revision of the deck as a new revision, returns the number of the new revision
+//
+// The revisions after the restored one are kept, so a rollback can be undone. If the note is empty, it says which revision was restored
+func (r DeckRepository) Rollback(name string, revision int, note string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	history, err := r.readHistory(name)
+	if err != nil {
+		return 0, err
+	}
+	if revision <= 0 || revision > len(history.Revisions) {
+		return 0, fmt.Errorf("mtgsdk - revision %d of deck %s not found: %w", revision, name, ErrNotFound)
+	}
+	if note == "" {
+		note = fmt.Sprintf("Rollback to revision %d", revision)
+	}
+	return r.addRevision(name, history.Revisions[revision-1].Deck, nil, note)
+}
+
+// Deletes the deck with all its revisions
+func (r DeckRepository) Delete(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	exists, err := r.adm.FileExists(deckHistoryFile(name))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("mtgsdk - deck %s not found: %w", name, ErrNotFound)
+	}
+	index, err := r.readIndex()
+	if err != nil {
+		return err
+	}
+	err = r.adm.RemoveFile(deckHistoryFile(name))
+	if err != nil {
+		return err
+	}
+	delete(index, name)
+	return r.writeIndex(index)
+}
@@ -0,0 +1,71 @@
+package mtgsdk
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Saves a deck with one card under the name
+func saveTestDeck(t *testing.T, decks DeckRepository, name string) {
+	t.Helper()
+	deck := CreateDeck(name)
+	deck.AddCard(&Card{ID: "sol-ring", Name: "Sol Ring"}, 1)
+	_, err := decks.Save(*deck, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Checks that the repository lists exactly the decks
+func checkDeckList(t *testing.T, decks DeckRepository, expected ...string) {
+	t.Helper()
+	names, err := decks.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected the decks %v, got %v", expected, names)
+	}
+}
+
+func TestDeckRepositoryListAndDelete(t *testing.T) {
+	client := newTestClient(t, nil)
+	decks := client.Decks()
+	saveTestDeck(t, decks, "Zada")
+	saveTestDeck(t, decks, "Atraxa")
+	saveTestDeck(t, decks, "Zada")
+	checkDeckList(t, decks, "Atraxa", "Zada")
+
+	// the list comes from the index, so the history files aren't read
+	err := client.adm.WriteToFile(deckHistoryFile("Zada"), []byte("not json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDeckList(t, decks, "Atraxa", "Zada")
+
+	err = decks.Delete("Zada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDeckList(t, decks, "Atraxa")
+	exists, err := client.adm.FileExists(deckHistoryFile("Zada"))
+	if err != nil || exists {
+		t.Fatalf("expected the history file to be removed (%v)", err)
+	}
+}
+
+func TestDeckRepositoryRebuildsIndex(t *testing.T) {
+	client := newTestClient(t, nil)
+	decks := client.Decks()
+	saveTestDeck(t, decks, "Atraxa")
+	saveTestDeck(t, decks, "Zada")
+	err := client.adm.RemoveFile(decksIndexFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDeckList(t, decks, "Atraxa", "Zada")
+	exists, err := client.adm.FileExists(decksIndexFile)
+	if err != nil || !exists {
+		t.Fatalf("expected the index to be rebuilt (%v)", err)
+	}
+}
@@ -0,0 +1,117 @@
+package mtgsdk
+
+import (
+	"context"
+	"log"
+	"net/url"
+)
+
+// A page of the scryfall card search
+type cardPage struct {
+	TotalCards int      `json:"total_cards"` // The total amount of cards found
+	HasMore    bool     `json:"has_more"`    // True if there are more pages
+	NextPage   string   `json:"next_page"`   // The url of the next page
+	Data       []Card   `json:"data"`        // The cards of the page
+	Warnings   []string `json:"warnings"`    // The warnings for the query
+}
+
+// An iterator over the results of a card search
+//
+// Fetches the next page only when the current one is exhausted, so the whole result set is never held in memory
+type CardIterator struct {
+	client   *Client         // the client that fetches the pages
+	ctx      context.Context // the context of the requests
+	nextURL  string          // the url of the next page (empty if there are no more pages)
+	page     []Card          // the cards of the current page
+	pos      int             // the position in the current page
+	limit    int             // the maximum amount of cards (not positive for no limit)
+	count    int             // the amount of cards returned
+	total    int             // the total amount of cards reported by scryfall
+	warnings []string        // the warnings reported by scryfall
+	card     Card            // the current card
+	err      error           // the first error
+}
+
+// Returns an iterator over the cards that match the params
+//
+// If limit is not positive, iterates over all the cards
+func (c *Client) IterCards(params map[string]string, limit int) *CardIterator {
+	return c.IterCardsContext(context.Background(), params, limit)
+}
+
+// Same as IterCards, but uses the context for the network requests
+func (c *Client) IterCardsContext(ctx context.Context, params map[string]string, limit int) *CardIterator {
+	return c.IterQueryContext(ctx, paramsToQuery(params), limit)
+}
+
+// Returns an iterator over the cards that match the query
+//
+// If limit is not positive, iterates over all the cards
+func (c *Client) IterQuery(q QueryNode, limit int) *CardIterator {
+	return c.IterQueryContext(context.Background(), q, limit)
+}
+
+// Same as IterQuery, but uses the context for the network requests
+func (c *Client) IterQueryContext(ctx context.Context, q QueryNode, limit int) *CardIterator {
+	log.Printf("mtgsdk - searching for cards with query %s", q)
+	return &CardIterator{
+		client:  c,
+		ctx:     ctx,
+		nextURL: c.apiURL + cardQuerySearchPath + url.QueryEscape(q.String()),
+		limit:   limit,
+	}
+}
+
+// Advances the iterator to the next card
+//
+// Returns false when there are no more cards or an error occured
+func (it *CardIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.limit > 0 && it.count >= it.limit {
+		return false
+	}
+	for it.pos >= len(it.page) {
+		if it.nextURL == "" {
+			return false
+		}
+		page, err := it.client.fetchCardPage(it.ctx, it.nextURL)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = page.Data
+		it.pos = 0
+		it.total = page.TotalCards
+		it.warnings = append(it.warnings, page.Warnings...)
+		it.nextURL = ""
+		if page.HasMore {
+			it.nextURL = page.NextPage
+		}
+	}
+	it.card = it.page[it.pos]
+	it.pos++
+	it.count++
+	return true
+}
+
+// Returns the current card
+func (it *CardIterator) Card() Card {
+	return it.card
+}
+
+// Returns the first error that occured during iteration
+func (it *CardIterator) Err() error {
+	return it.err
+}
+
+// Returns the total amount of cards that match the search (known after the first call to Next)
+func (it *CardIterator) Total() int {
+	return it.total
+}
+
+// Returns the warnings scryfall reported for the search
+func (it *CardIterator) Warnings() []string {
+	return it.warnings
+}
@@ -0,0 +1,73 @@
+package mtgsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	searchTestPages    = 3 // the amount of pages served by the search test api
+	searchTestPageSize = 2 // the amount of cards on every page
+)
+
+// Returns a fake search endpoint that serves the pages of the test search and counts the requests
+func serveSearchPages(requests *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		result := cardPage{TotalCards: searchTestPages * searchTestPageSize, Data: []Card{}, Warnings: []string{fmt.Sprintf("page %d", page)}}
+		for i := 0; i < searchTestPageSize; i++ {
+			result.Data = append(result.Data, Card{ID: testCardID((page-1)*searchTestPageSize + i)})
+		}
+		if page < searchTestPages {
+			result.HasMore = true
+			result.NextPage = fmt.Sprintf("http://%s%s?page=%d", r.Host, r.URL.Path, page+1)
+		}
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+func TestCardIteratorPages(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, serveSearchPages(&requests))
+	it := client.IterQuery(Field(nameQueryKey, Has, "card"), 0)
+	count := 0
+	for it.Next() {
+		if it.Card().ID != testCardID(count) {
+			t.Fatalf("expected the card %s, got %s", testCardID(count), it.Card().ID)
+		}
+		count++
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+	if count != searchTestPages*searchTestPageSize || it.Total() != count {
+		t.Fatalf("expected %d cards of every page, got %d (total %d)", searchTestPages*searchTestPageSize, count, it.Total())
+	}
+	if requests != searchTestPages || len(it.Warnings()) != searchTestPages {
+		t.Fatalf("expected %d pages with their warnings, got %d requests and %v", searchTestPages, requests, it.Warnings())
+	}
+}
+
+func TestCardIteratorLimit(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, serveSearchPages(&requests))
+	cards, err := client.FetchQueryLimit(Field(nameQueryKey, Has, "card"), searchTestPageSize+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != searchTestPageSize+1 {
+		t.Fatalf("expected %d cards, got %d", searchTestPageSize+1, len(cards))
+	}
+	// the last page isn't needed
+	if requests != 2 {
+		t.Fatalf("expected 2 page requests, got %d", requests)
+	}
+}
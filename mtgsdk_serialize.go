@@ -0,0 +1,199 @@
+package mtgsdk
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DeckSchemaVersion = 1 // The version of the deck document format
+)
+
+var (
+	// The names of the deck orders in deck documents
+	orderNames = map[DeckOrder]string{
+		OrderInsertion: "insertion",
+		OrderName:      "name",
+		OrderType:      "type",
+		OrderCMC:       "cmc",
+	}
+)
+
+// A serializable representation of a deck
+type DeckDocument struct {
+	SchemaVersion int                          `json:"schema_version" yaml:"schema_version"`   // The version of the format
+	Name          string                       `json:"name" yaml:"name"`                       // The name of the deck
+	Order         string                       `json:"order,omitempty" yaml:"order,omitempty"` // The order of the cards (insertion, name, type or cmc)
+	Tags          []string                     `json:"tags,omitempty" yaml:"tags,omitempty"`   // The tags of the deck
+	Notes         string                       `json:"notes,omitempty" yaml:"notes,omitempty"` // The notes of the deck
+	Zones         map[Zone][]DeckDocumentEntry `json:"zones" yaml:"zones"`                     // The cards of the zones
+}
+
+// A printing of a card in a deck document
+type DeckDocumentEntry struct {
+	Amount          int    `json:"amount" yaml:"amount"`
+	Name            string `json:"name" yaml:"name"`
+	ID              string `json:"id,omitempty" yaml:"id,omitempty"`
+	OracleID        string `json:"oracle_id,omitempty" yaml:"oracle_id,omitempty"`
+	Set             string `json:"set,omitempty" yaml:"set,omitempty"`
+	CollectorNumber string `json:"collector_number,omitempty" yaml:"collector_number,omitempty"`
+	Finish          Finish `json:"finish,omitempty" yaml:"finish,omitempty"`
+	Card            *Card  `json:"card,omitempty" yaml:"card,omitempty"` // The snapshot of the card data (optional)
+}
+
+// Returns the document of the deck
+//
+// If embedCards is true, the full card data is included, so the deck can be read without looking up the cards
+func (d Deck) Document(embedCards bool) DeckDocument {
+	result := DeckDocument{
+		SchemaVersion: DeckSchemaVersion,
+		Name:          d.Name,
+		Order:         orderNames[d.order],
+		Tags:          d.Tags,
+		Notes:         d.Notes,
+		Zones:         map[Zone][]DeckDocumentEntry{},
+	}
+	for _, zone := range d.usedZones() {
+		// the cards are written in the insertion order, the order of the deck is stored separately
+		entries := []DeckDocumentEntry{}
+		for _, entry := range d.getZone(zone).entries {
+			card := entry.card
+			documentEntry := DeckDocumentEntry{
+				Amount:          entry.amount,
+				Name:            card.Name,
+				ID:              card.ID,
+				OracleID:        card.OracleID,
+				Set:             card.Set,
+				CollectorNumber: card.CollectorNumber,
+			}
+			if entry.finish != FinishNonfoil {
+				documentEntry.Finish = entry.finish
+			}
+			if embedCards {
+				snapshot := card
+				documentEntry.Card = &snapshot
+			}
+			entries = append(entries, documentEntry)
+		}
+		result.Zones[zone] = entries
+	}
+	return result
+}
+
+// Returns the deck of the document
+//
+// Entries without a card snapshot get a card with only the name, ids, set and collector number filled in
+func (doc DeckDocument) Deck() (Deck, error) {
+	if doc.SchemaVersion <= 0 || doc.SchemaVersion > DeckSchemaVersion {
+		return Deck{}, fmt.Errorf("mtgsdk - unsupported deck schema version %d", doc.SchemaVersion)
+	}
+	result := Deck{Name: doc.Name, Tags: doc.Tags, Notes: doc.Notes}
+	if doc.Order != "" {
+		found := false
+		for order, name := range orderNames {
+			if name == doc.Order {
+				result.order = order
+				found = true
+			}
+		}
+		if !found {
+			return Deck{}, fmt.Errorf("mtgsdk - unknown deck order %s", doc.Order)
+		}
+	}
+	// the zones are added in order, so the insertion order doesn't depend on the map order
+	for _, zone := range zoneOrder {
+		for _, entry := range doc.Zones[zone] {
+			card := Card{ID: entry.ID, OracleID: entry.OracleID, Name: entry.Name, Set: entry.Set, CollectorNumber: entry.CollectorNumber}
+			if entry.Card != nil {
+				card = *entry.Card
+			}
+			if card.ID == "" {
+				return Deck{}, fmt.Errorf("mtgsdk - card %s in zone %s doesn't have an id", entry.Name, zone)
+			}
+			result.AddToZoneWithFinish(zone, &card, entry.Amount, entry.Finish)
+		}
+	}
+	for zone := range doc.Zones {
+		if !isKnownZone(zone) {
+			return Deck{}, fmt.Errorf("mtgsdk - unknown deck zone %s", zone)
+		}
+	}
+	return result, nil
+}
+
+// Returns true if the zone is one of the deck zones
+func isKnownZone(zone Zone) bool {
+	for _, z := range zoneOrder {
+		if z == zone {
+			return true
+		}
+	}
+	return false
+}
+
+// Marshals the deck to json with the card data embedded
+func (d Deck) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Document(true))
+}
+
+// Unmarshals the deck from json
+func (d *Deck) UnmarshalJSON(data []byte) error {
+	var doc DeckDocument
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return err
+	}
+	result, err := doc.Deck()
+	if err != nil {
+		return err
+	}
+	*d = result
+	return nil
+}
+
+// Marshals the deck to yaml with the card data embedded
+func (d Deck) MarshalYAML() (interface{}, error) {
+	return d.Document(true), nil
+}
+
+// Unmarshals the deck from yaml
+func (d *Deck) UnmarshalYAML(value *yaml.Node) error {
+	var doc DeckDocument
+	err := value.Decode(&doc)
+	if err != nil {
+		return err
+	}
+	result, err := doc.Deck()
+	if err != nil {
+		return err
+	}
+	*d = result
+	return nil
+}
+
+// Marshals the card to yaml with the keys of the scryfall json
+func (c Card) MarshalYAML() (interface{}, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
+
+// Unmarshals the card from yaml with the keys of the scryfall json
+func (c *Card) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	err := value.Decode(&raw)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, c)
+}
@@ -0,0 +1,98 @@
+package mtgsdk
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Checks that the deck read back from a document matches the export test deck
+func checkRoundTrip(t *testing.T, deck, read Deck) {
+	t.Helper()
+	if got, expected := deckSummary(read), deckSummary(deck); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected the entries %v, got %v", expected, got)
+	}
+	if read.Name != deck.Name || read.Notes != deck.Notes || !reflect.DeepEqual(read.Tags, deck.Tags) {
+		t.Fatalf("expected the deck %q %q %v, got %q %q %v", deck.Name, deck.Notes, deck.Tags, read.Name, read.Notes, read.Tags)
+	}
+	// the cards are embedded, so the prices survive
+	for _, entry := range read.Entries() {
+		if entry.Card.ID == "sol-ring" && entry.Card.Prices.USDFoil != "4.00" {
+			t.Fatalf("expected the embedded Sol Ring with its prices, got %v", entry.Card.Prices)
+		}
+	}
+
+	// the order is stored separately from the insertion order
+	read.SetOrder(OrderInsertion)
+	names := []string{}
+	for _, entry := range read.Entries() {
+		names = append(names, entry.Card.Name)
+	}
+	if expected := []string{"Sol Ring", "Sol Ring", "Forest"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected the insertion order %v, got %v", expected, names)
+	}
+}
+
+// Returns the export test deck sorted by name, with tags and notes
+func serializeTestDeck() Deck {
+	deck := exportTestDeck()
+	deck.Tags = []string{"edh", "budget"}
+	deck.Notes = "Proliferate"
+	deck.SetOrder(OrderName)
+	return deck
+}
+
+func TestDeckJSONRoundTrip(t *testing.T) {
+	deck := serializeTestDeck()
+	data, err := json.Marshal(deck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var read Deck
+	err = json.Unmarshal(data, &read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRoundTrip(t, deck, read)
+}
+
+func TestDeckYAMLRoundTrip(t *testing.T) {
+	deck := serializeTestDeck()
+	data, err := yaml.Marshal(deck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var read Deck
+	err = yaml.Unmarshal(data, &read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRoundTrip(t, deck, read)
+}
+
+func TestDeckDocumentSchemaVersion(t *testing.T) {
+	for _, version := range []int{0, DeckSchemaVersion + 1} {
+		doc := exportTestDeck().Document(false)
+		doc.SchemaVersion = version
+		data, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var read Deck
+		err = json.Unmarshal(data, &read)
+		if err == nil || !strings.Contains(err.Error(), "schema version") {
+			t.Fatalf("expected the json schema version %d to be rejected, got %v", version, err)
+		}
+		data, err = yaml.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = yaml.Unmarshal(data, &read)
+		if err == nil || !strings.Contains(err.Error(), "schema version") {
+			t.Fatalf("expected the yaml schema version %d to be rejected, got %v", version, err)
+		}
+	}
+}
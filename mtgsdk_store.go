@@ -0,0 +1,336 @@
+package mtgsdk
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	boltCardsFileName = "all_cards.db" // the path to the card database file
+	storeBatchSize    = 1000           // the amount of cards written in a single batch
+
+	boltCardsBucket    = "cards"     // the bucket of the cards (card.id -- card json)
+	boltNameIndex      = "name"      // the index of card names
+	boltOracleIDIndex  = "oracle_id" // the index of card oracle ids
+	boltSetIndex       = "set"       // the index of card sets
+	boltIndexSeparator = "\x00"      // separates the indexed value from the card id
+)
+
+// A storage of the local cards
+//
+// Implementations must be safe for concurrent use
+type CardStore interface {
+	Get(id string) (Card, bool, error)              // Returns the card with the id (false if not found)
+	Put(card Card) error                            // Inserts or replaces the card
+	PutBatch(cards []Card) error                    // Inserts or replaces the cards
+	Each(f func(card Card) bool) error              // Calls f for every card, stops if f returns false (f must not write to the store)
+	FindByName(name string) ([]Card, error)         // Returns the cards with the name (case insensitive)
+	FindByOracleID(oracleID string) ([]Card, error) // Returns the cards with the oracle id
+	FindBySet(setCode string) ([]Card, error)       // Returns the cards from the set (case insensitive)
+	Len() (int, error)                              // Returns the amount of cards
+	Close() error                                   // Releases the resources of the store
+}
+
+// A card store that keeps the cards in memory and in a single json file
+//
+// Rewrites the whole file on every write, so PutBatch should be preferred. Bulk data can't be imported into it,
+// use BoltCardStore for big collections. Safe for concurrent use
+type JSONCardStore struct {
+	path  string          // the path to the json file
+	cards map[string]Card // the map of all cards
+	mu    sync.RWMutex    // guards cards and the file
+}
+
+// Opens the json card store at the path, creating the file if it doesn't exist
+func OpenJSONCardStore(path string) (*JSONCardStore, error) {
+	result := &JSONCardStore{path: path, cards: map[string]Card{}}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return result, result.save()
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &result.cards)
+	if err != nil {
+		return nil, err
+	}
+	if result.cards == nil {
+		result.cards = map[string]Card{}
+	}
+	return result, nil
+}
+
+// Saves the cards into the json file
+//
+// The caller must hold the lock
+func (s *JSONCardStore) save() error {
+	data, err := json.MarshalIndent(s.cards, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0755)
+}
+
+func (s *JSONCardStore) Get(id string) (Card, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	card, has := s.cards[id]
+	return card, has, nil
+}
+
+func (s *JSONCardStore) Put(card Card) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cards[card.ID] = card
+	return s.save()
+}
+
+func (s *JSONCardStore) PutBatch(cards []Card) error {
+	if len(cards) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, card := range cards {
+		s.cards[card.ID] = card
+	}
+	return s.save()
+}
+
+// Calls f for a snapshot of the cards, so f can use the store
+func (s *JSONCardStore) Each(f func(card Card) bool) error {
+	s.mu.RLock()
+	cards := make([]Card, 0, len(s.cards))
+	for _, card := range s.cards {
+		cards = append(cards, card)
+	}
+	s.mu.RUnlock()
+	for _, card := range cards {
+		if !f(card) {
+			break
+		}
+	}
+	return nil
+}
+
+// Returns the cards that match the filter
+func (s *JSONCardStore) filter(match func(card Card) bool) []Card {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := []Card{}
+	for _, card := range s.cards {
+		if match(card) {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+func (s *JSONCardStore) FindByName(name string) ([]Card, error) {
+	return s.filter(func(card Card) bool { return strings.EqualFold(card.Name, name) }), nil
+}
+
+func (s *JSONCardStore) FindByOracleID(oracleID string) ([]Card, error) {
+	return s.filter(func(card Card) bool { return card.OracleID == oracleID }), nil
+}
+
+func (s *JSONCardStore) FindBySet(setCode string) ([]Card, error) {
+	return s.filter(func(card Card) bool { return strings.EqualFold(card.Set, setCode) }), nil
+}
+
+func (s *JSONCardStore) Len() (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.cards), nil
+}
+
+func (s *JSONCardStore) Close() error {
+	return nil
+}
+
+// A card store backed by an embedded bbolt database
+//
+// Keeps secondary indexes on card name, oracle id and set. Safe for concurrent use
+type BoltCardStore struct {
+	db *bolt.DB // the database
+}
+
+// Opens the bbolt card store at the path, creating the database if it doesn't exist
+func OpenBoltCardStore(path string) (*BoltCardStore, error) {
+	db, err := bolt.Open(path, 0644, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range []string{boltCardsBucket, boltNameIndex, boltOracleIDIndex, boltSetIndex} {
+			_, err := tx.CreateBucketIfNotExists([]byte(name))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltCardStore{db: db}, nil
+}
+
+// Returns the index keys of the card (bucket name -- key)
+func boltIndexKeys(card Card) map[string][]byte {
+	return map[string][]byte{
+		boltNameIndex:     []byte(strings.ToLower(card.Name) + boltIndexSeparator + card.ID),
+		boltOracleIDIndex: []byte(card.OracleID + boltIndexSeparator + card.ID),
+		boltSetIndex:      []byte(strings.ToLower(card.Set) + boltIndexSeparator + card.ID),
+	}
+}
+
+// Inserts or replaces the card in the transaction, updating the indexes
+func boltPut(tx *bolt.Tx, card Card) error {
+	cards := tx.Bucket([]byte(boltCardsBucket))
+	// remove the index entries of the old version of the card
+	if old := cards.Get([]byte(card.ID)); old != nil {
+		var oldCard Card
+		err := json.Unmarshal(old, &oldCard)
+		if err != nil {
+			return err
+		}
+		for bucket, key := range boltIndexKeys(oldCard) {
+			err = tx.Bucket([]byte(bucket)).Delete(key)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	data, err := json.Marshal(card)
+	if err != nil {
+		return err
+	}
+	err = cards.Put([]byte(card.ID), data)
+	if err != nil {
+		return err
+	}
+	for bucket, key := range boltIndexKeys(card) {
+		err = tx.Bucket([]byte(bucket)).Put(key, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BoltCardStore) Get(id string) (Card, bool, error) {
+	var result Card
+	found := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket([]byte(boltCardsBucket)).Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(data, &result)
+	})
+	return result, found, err
+}
+
+func (s *BoltCardStore) Put(card Card) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return boltPut(tx, card)
+	})
+}
+
+// Writes the cards in transactions of storeBatchSize cards
+func (s *BoltCardStore) PutBatch(cards []Card) error {
+	for start := 0; start < len(cards); start += storeBatchSize {
+		end := start + storeBatchSize
+		if end > len(cards) {
+			end = len(cards)
+		}
+		err := s.db.Update(func(tx *bolt.Tx) error {
+			for _, card := range cards[start:end] {
+				err := boltPut(tx, card)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BoltCardStore) Each(f func(card Card) bool) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket([]byte(boltCardsBucket)).Cursor()
+		for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+			var card Card
+			err := json.Unmarshal(v, &card)
+			if err != nil {
+				return err
+			}
+			if !f(card) {
+				break
+			}
+		}
+		return nil
+	})
+}
+
+// Returns the cards which index entries start with the value
+func (s *BoltCardStore) findByIndex(bucket string, value string) ([]Card, error) {
+	result := []Card{}
+	prefix := []byte(value + boltIndexSeparator)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cards := tx.Bucket([]byte(boltCardsBucket))
+		cursor := tx.Bucket([]byte(bucket)).Cursor()
+		for k, _ := cursor.Seek(prefix); k != nil && strings.HasPrefix(string(k), string(prefix)); k, _ = cursor.Next() {
+			id := k[len(prefix):]
+			data := cards.Get(id)
+			if data == nil {
+				continue
+			}
+			var card Card
+			err := json.Unmarshal(data, &card)
+			if err != nil {
+				return err
+			}
+			result = append(result, card)
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (s *BoltCardStore) FindByName(name string) ([]Card, error) {
+	return s.findByIndex(boltNameIndex, strings.ToLower(name))
+}
+
+func (s *BoltCardStore) FindByOracleID(oracleID string) ([]Card, error) {
+	return s.findByIndex(boltOracleIDIndex, oracleID)
+}
+
+func (s *BoltCardStore) FindBySet(setCode string) ([]Card, error) {
+	return s.findByIndex(boltSetIndex, strings.ToLower(setCode))
+}
+
+func (s *BoltCardStore) Len() (int, error) {
+	result := 0
+	err := s.db.View(func(tx *bolt.Tx) error {
+		result = tx.Bucket([]byte(boltCardsBucket)).Stats().KeyN
+		return nil
+	})
+	return result, err
+}
+
+func (s *BoltCardStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,91 @@
+package mtgsdk
+
+import (
+	"fmt"
+	"path"
+	"sync"
+	"testing"
+)
+
+func TestJSONCardStoreConcurrent(t *testing.T) {
+	storePath := path.Join(t.TempDir(), allCardsFileName)
+	store, err := OpenJSONCardStore(storePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			card := Card{ID: fmt.Sprintf("card-%d", i), Name: fmt.Sprintf("Card %d", i)}
+			if i%5 == 0 {
+				errs <- store.PutBatch([]Card{card})
+				return
+			}
+			errs <- store.Put(card)
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			// the card may not be written yet, but reading it must not race with the writes
+			_, _, err := store.Get(fmt.Sprintf("card-%d", i))
+			if err == nil {
+				_, err = store.FindByName(fmt.Sprintf("Card %d", i))
+			}
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = store.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every write must be in the file
+	reopened, err := OpenJSONCardStore(storePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	count, err := reopened.Len()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 50 {
+		t.Fatalf("expected 50 cards in the reopened store, got %d", count)
+	}
+	card, found, err := reopened.Get("card-7")
+	if err != nil || !found || card.Name != "Card 7" {
+		t.Fatalf("expected card-7 in the reopened store, got %v %v %v", card, found, err)
+	}
+}
+
+func TestBoltCardStoreMigratesJSONCache(t *testing.T) {
+	dir := t.TempDir()
+	jsonStore, err := OpenJSONCardStore(path.Join(dir, allCardsFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = jsonStore.PutBatch([]Card{{ID: "sol-ring", Name: "Sol Ring"}, {ID: "forest", Name: "Forest"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newTestClient(t, nil, WithDataDir(dir))
+	if _, isBolt := client.Store().(*BoltCardStore); !isBolt {
+		t.Fatalf("expected the bbolt card store by default, got %T", client.Store())
+	}
+	count, err := client.Store().Len()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("expected the 2 cards of the json cache in the database, got %d", count)
+	}
+}
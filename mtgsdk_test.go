@@ -0,0 +1,152 @@
+package mtgsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testCardCount = 5 // the amount of cards served by the test api
+)
+
+// Returns the id of the test card with the index
+func testCardID(i int) string {
+	return fmt.Sprintf("card-%d", i)
+}
+
+// Creates a client that stores its data in a temporary directory
+//
+// If handler is not nil, the client uses a local server with the handler as the scryfall api. The options are applied after the defaults
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) *Client {
+	opts = append([]ClientOption{WithDataDir(t.TempDir()), WithoutRateLimit()}, opts...)
+	if handler != nil {
+		server := httptest.NewServer(handler)
+		t.Cleanup(server.Close)
+		opts = append(opts, WithAPIURL(server.URL))
+	}
+	client, err := NewClient(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// A fake scryfall api that serves the test cards and their images
+func serveTestCards(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasPrefix(r.URL.Path, cardIDSearchPath):
+		id := strings.TrimPrefix(r.URL.Path, cardIDSearchPath)
+		card := Card{ID: id, Name: "Card " + id, ImageUris: ImageURIs{Normal: "http://" + r.Host + "/images/" + id}}
+		json.NewEncoder(w).Encode(card)
+	case strings.HasPrefix(r.URL.Path, "/images/"):
+		fmt.Fprintf(w, "image of %s", path.Base(r.URL.Path))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestGetCardConcurrent(t *testing.T) {
+	client := newTestClient(t, serveTestCards)
+	var wg sync.WaitGroup
+	errs := make(chan error, 40)
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := testCardID(i % testCardCount)
+			var card Card
+			var err error
+			if i%2 == 0 {
+				card, err = client.GetCard(id)
+			} else {
+				card, err = client.GetCardContext(context.Background(), id)
+			}
+			if err == nil && card.ID != id {
+				err = fmt.Errorf("expected card %s, got %s", id, card.ID)
+			}
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	count, err := client.Store().Len()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != testCardCount {
+		t.Fatalf("expected %d cards in the store, got %d", testCardCount, count)
+	}
+}
+
+func TestDownloadCardImagesConcurrent(t *testing.T) {
+	client := newTestClient(t, serveTestCards)
+	for i := 0; i < testCardCount; i++ {
+		_, err := client.GetCard(testCardID(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	outPath := t.TempDir()
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- client.DownloadCardImages(nil, "", outPath, ImageQualityNormal, true)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < testCardCount; i++ {
+		id := testCardID(i)
+		data, err := os.ReadFile(path.Join(outPath, fmt.Sprintf("%s_normal.%s", id, imageFileFormat)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "image of "+id {
+			t.Fatalf("unexpected image of %s: %q", id, data)
+		}
+	}
+}
+
+// Returns a fake collection endpoint that looks up the identifiers in the cards
+func serveCardList(cards []Card) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body collectionRequest
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response := collectionResponse{Data: []Card{}, NotFound: []CardIdentifier{}}
+		for _, identifier := range body.Identifiers {
+			card, found := pickPrinting(cards, identifier.matches)
+			if !found {
+				response.NotFound = append(response.NotFound, identifier)
+				continue
+			}
+			response.Data = append(response.Data, card)
+		}
+		json.NewEncoder(w).Encode(response)
+	}
+}
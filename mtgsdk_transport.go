@@ -0,0 +1,155 @@
+package mtgsdk
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRequestInterval = 100 * time.Millisecond // The default delay between requests (as requested by scryfall)
+	DefaultMaxRetries      = 3                      // The default amount of retries for a failed request
+	DefaultBaseBackoff     = 500 * time.Millisecond // The default delay before the first retry
+	DefaultMaxBackoff      = 30 * time.Second       // The default maximum delay between retries
+)
+
+// A http transport that spaces out requests and retries the throttled and failed ones
+//
+// Retries 429 responses after the Retry-After delay and 5xx responses with exponential backoff and jitter
+type RateLimitedTransport struct {
+	Base        http.RoundTripper // The underlying transport (http.DefaultTransport if nil)
+	Interval    time.Duration     // The minimum delay between two requests
+	MaxRetries  int               // The maximum amount of retries for a request
+	BaseBackoff time.Duration     // The delay before the first retry of a 5xx response
+	MaxBackoff  time.Duration     // The maximum delay between retries
+
+	mu   sync.Mutex // guards next
+	next time.Time  // the earliest time the next request can be sent
+}
+
+// Creates a rate limited transport with the default limits
+func NewRateLimitedTransport(base http.RoundTripper) *RateLimitedTransport {
+	return &RateLimitedTransport{
+		Base:        base,
+		Interval:    DefaultRequestInterval,
+		MaxRetries:  DefaultMaxRetries,
+		BaseBackoff: DefaultBaseBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+// Sends the request, waiting for its turn and retrying it if needed
+func (t *RateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for attempt := 0; ; attempt++ {
+		err := sleepContext(req, t.reserve())
+		if err != nil {
+			return nil, err
+		}
+		r := req
+		if attempt > 0 && req.Body != nil && req.GetBody != nil {
+			// the body of the previous attempt is already consumed
+			r = req.Clone(req.Context())
+			r.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+		resp, err := base.RoundTrip(r)
+		if err != nil {
+			return nil, err
+		}
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		if !retryable || attempt >= t.MaxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+		delay := t.backoff(attempt)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = after
+			}
+		}
+		resp.Body.Close()
+		err = sleepContext(req, delay)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Reserves the next request slot
+//
+// Returns the time to wait before sending the request
+func (t *RateLimitedTransport) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	wait := t.next.Sub(now)
+	t.next = t.next.Add(t.Interval)
+	return wait
+}
+
+// Returns the exponential backoff delay with jitter for the attempt
+func (t *RateLimitedTransport) backoff(attempt int) time.Duration {
+	if t.BaseBackoff <= 0 {
+		return 0
+	}
+	if attempt > 30 {
+		// the shift overflows for big attempts
+		attempt = 30
+	}
+	delay := t.BaseBackoff << uint(attempt)
+	if delay <= 0 || (t.MaxBackoff > 0 && delay > t.MaxBackoff) {
+		delay = t.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// jitter between half and the whole delay
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// Parses the Retry-After header (either seconds or a http date)
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	delay := time.Until(date)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
+
+// Sleeps for the duration or until the request is cancelled
+func sleepContext(req *http.Request, d time.Duration) error {
+	if d <= 0 {
+		return req.Context().Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
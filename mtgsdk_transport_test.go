@@ -0,0 +1,139 @@
+package mtgsdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Returns a server that responds with the statuses in order (the last one is repeated) and records the request times
+func newStatusServer(t *testing.T, header http.Header, statuses ...int) (*httptest.Server, func() []time.Time) {
+	var mu sync.Mutex
+	times := []time.Time{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		status := statuses[len(statuses)-1]
+		if len(times) <= len(statuses) {
+			status = statuses[len(times)-1]
+		}
+		mu.Unlock()
+		for key, values := range header {
+			if status != http.StatusOK {
+				w.Header()[key] = values
+			}
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]time.Time{}, times...)
+	}
+}
+
+func TestRateLimitedTransportRetryAfter(t *testing.T) {
+	server, times := newStatusServer(t, http.Header{"Retry-After": {"1"}}, http.StatusTooManyRequests, http.StatusOK)
+	transport := &RateLimitedTransport{MaxRetries: 3, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	requests := times()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	// the backoff is 1ms, so the delay can only come from the Retry-After header
+	if gap := requests[1].Sub(requests[0]); gap < time.Second {
+		t.Fatalf("expected the retry to wait for Retry-After (1s), waited %v", gap)
+	}
+}
+
+func TestRateLimitedTransportBackoff(t *testing.T) {
+	server, times := newStatusServer(t, nil, http.StatusServiceUnavailable)
+	base := 20 * time.Millisecond
+	transport := &RateLimitedTransport{MaxRetries: 3, BaseBackoff: base, MaxBackoff: time.Second}
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected the last 503 response after the retries, got %d", resp.StatusCode)
+	}
+	requests := times()
+	if len(requests) != transport.MaxRetries+1 {
+		t.Fatalf("expected %d requests, got %d", transport.MaxRetries+1, len(requests))
+	}
+	for i := 1; i < len(requests); i++ {
+		// the jitter keeps at least half of the exponential delay
+		min := (base << uint(i-1)) / 2
+		if gap := requests[i].Sub(requests[i-1]); gap < min {
+			t.Fatalf("retry %d waited %v, expected at least %v", i, gap, min)
+		}
+	}
+}
+
+func TestRateLimitedTransportBackoffCap(t *testing.T) {
+	transport := &RateLimitedTransport{BaseBackoff: 20 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+	for attempt := 0; attempt < 40; attempt++ {
+		if delay := transport.backoff(attempt); delay > transport.MaxBackoff || delay < 0 {
+			t.Fatalf("backoff of attempt %d is %v, expected at most %v", attempt, delay, transport.MaxBackoff)
+		}
+	}
+}
+
+func TestRateLimitedTransportContextCancel(t *testing.T) {
+	server, times := newStatusServer(t, http.Header{"Retry-After": {"10"}}, http.StatusTooManyRequests)
+	client := &http.Client{Transport: NewRateLimitedTransport(nil)}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the request to be cancelled")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("the cancelled request kept waiting for %v", elapsed)
+	}
+	if len(times()) != 1 {
+		t.Fatalf("expected 1 request before the cancellation, got %d", len(times()))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if delay, ok := parseRetryAfter("3"); !ok || delay != 3*time.Second {
+		t.Fatalf("expected 3s, got %v (%v)", delay, ok)
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if delay, ok := parseRetryAfter(date); !ok || delay <= 0 {
+		t.Fatalf("expected a positive delay for %s, got %v (%v)", date, delay, ok)
+	}
+	for _, value := range []string{"", "-1", "soon"} {
+		if _, ok := parseRetryAfter(value); ok {
+			t.Fatalf("expected %q to be invalid", value)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package mtgsdk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A zone of a deck
+type Zone string
+
+const (
+	ZoneCommander  Zone = "Commander"  // The commanders (or the oathbreaker and the signature spell)
+	ZoneCompanion  Zone = "Companion"  // The companion
+	ZoneMain       Zone = "Deck"       // The main deck
+	ZoneSideboard  Zone = "Sideboard"  // The sideboard
+	ZoneMaybeboard Zone = "Maybeboard" // The cards that are considered for the deck (not a part of the deck)
+)
+
+var (
+	// The zones in the order they are saved and printed
+	zoneOrder = []Zone{ZoneCommander, ZoneCompanion, ZoneMain, ZoneSideboard, ZoneMaybeboard}
+)
+
+// The copies of a printing of a card with a finish in a deck zone
+type zoneEntry struct {
+	card   Card   // The card
+	finish Finish // The finish of the copies
+	amount int    // The amount of copies
+}
+
+// The cards of a deck zone
+type deckZone struct {
+	entries []zoneEntry // The entries (in the order they were added), keyed by the card id and the finish
+}
+
+// Returns the finish, nonfoil if it's empty
+func normalizeFinish(finish Finish) Finish {
+	if finish == "" {
+		return FinishNonfoil
+	}
+	return finish
+}
+
+// Returns the index of the entry of the card with the finish (-1 if the zone doesn't have it)
+func (z *deckZone) index(cardID string, finish Finish) int {
+	finish = normalizeFinish(finish)
+	for i, entry := range z.entries {
+		if entry.card.ID == cardID && entry.finish == finish {
+			return i
+		}
+	}
+	return -1
+}
+
+// Adds the copies of the card with the finish to the zone
+func (z *deckZone) add(card Card, finish Finish, amount int) {
+	if amount <= 0 {
+		return
+	}
+	if i := z.index(card.ID, finish); i != -1 {
+		z.entries[i].amount += amount
+		return
+	}
+	z.entries = append(z.entries, zoneEntry{card: card, finish: normalizeFinish(finish), amount: amount})
+}
+
+// Removes the copies of the card from the zone (the finishes are removed in the order they were added)
+//
+// Returns the removed copies of every finish
+func (z *deckZone) remove(cardID string, amount int) []zoneEntry {
+	removed := []zoneEntry{}
+	kept := z.entries[:0]
+	for _, entry := range z.entries {
+		if entry.card.ID != cardID || amount <= 0 {
+			kept = append(kept, entry)
+			continue
+		}
+		taken := entry
+		if amount < entry.amount {
+			taken.amount = amount
+			entry.amount -= amount
+			kept = append(kept, entry)
+		}
+		amount -= taken.amount
+		removed = append(removed, taken)
+	}
+	z.entries = kept
+	return removed
+}
+
+// Returns the amount of copies of the card in the zone (of every finish)
+func (z *deckZone) count(cardID string) int {
+	result := 0
+	for _, entry := range z.entries {
+		if entry.card.ID == cardID {
+			result += entry.amount
+		}
+	}
+	return result
+}
+
+// Returns the cards of the zone without repeating the cards with several finishes (in the order they were added)
+func (z *deckZone) uniqueCards() []Card {
+	result := []Card{}
+	added := map[string]bool{}
+	for _, entry := range z.entries {
+		if !added[entry.card.ID] {
+			added[entry.card.ID] = true
+			result = append(result, entry.card)
+		}
+	}
+	return result
+}
+
+// Returns the amount of cards in the zone
+func (z *deckZone) size() int {
+	result := 0
+	for _, entry := range z.entries {
+		result += entry.amount
+	}
+	return result
+}
+
+// Returns the zone, creating it if it doesn't exist
+func (d *Deck) zone(zone Zone) *deckZone {
+	if d.zones == nil {
+		d.zones = map[Zone]*deckZone{}
+	}
+	result, has := d.zones[zone]
+	if !has {
+		result = &deckZone{}
+		d.zones[zone] = result
+	}
+	return result
+}
+
+// Returns the zone (empty if it doesn't exist)
+func (d Deck) getZone(zone Zone) *deckZone {
+	if result, has := d.zones[zone]; has {
+		return result
+	}
+	return &deckZone{}
+}
+
+// Adds the copies of the card to the zone of the deck (nonfoil)
+func (d *Deck) AddToZone(zone Zone, card *Card, amount int) {
+	d.AddToZoneWithFinish(zone, card, amount, FinishNonfoil)
+}
+
+// Adds the copies of the card with the finish to the zone of the deck
+//
+// The copies of different finishes are separate entries of the zone
+func (d *Deck) AddToZoneWithFinish(zone Zone, card *Card, amount int, finish Finish) {
+	d.zone(zone).add(*card, finish, amount)
+}
+
+// Removes the copies of the card from the zone of the deck
+//
+// If amount is not less than the amount of copies, removes the card from the zone. Returns the amount of removed copies
+func (d *Deck) RemoveFromZone(zone Zone, cardID string, amount int) int {
+	if amount <= 0 {
+		return 0
+	}
+	result := 0
+	for _, entry := range d.zone(zone).remove(cardID, amount) {
+		result += entry.amount
+	}
+	return result
+}
+
+// Moves the copies of the card from one zone of the deck to another (the copies keep their finishes)
+func (d *Deck) MoveCard(cardID string, from Zone, to Zone, amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("mtgsdk - can't move %d copies of card %s", amount, cardID)
+	}
+	if d.CountInZone(from, cardID) < amount {
+		return fmt.Errorf("mtgsdk - can't move %d copies of card %s from %s, it only has %d", amount, cardID, from, d.CountInZone(from, cardID))
+	}
+	target := d.zone(to)
+	for _, entry := range d.zone(from).remove(cardID, amount) {
+		target.add(entry.card, entry.finish, entry.amount)
+	}
+	return nil
+}
+
+// Returns the number of card instances in the zone of the deck (of every finish)
+func (d Deck) CountInZone(zone Zone, cardID string) int {
+	return d.getZone(zone).count(cardID)
+}
+
+// Returns the printings in the zone of the deck with their amounts and finishes (in the order of the deck)
+//
+// A card with copies of several finishes has an entry for every finish
+func (d Deck) ZoneEntries(zone Zone) []DeckEntry {
+	entries := d.orderedEntries(zone)
+	result := make([]DeckEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, DeckEntry{Card: entry.card, Amount: entry.amount, Finish: entry.finish})
+	}
+	return result
+}
+
+// Returns the unique cards of all the zones of the deck
+func (d Deck) allCards() []Card {
+	result := []Card{}
+	added := map[string]bool{}
+	for _, zone := range zoneOrder {
+		for _, card := range d.getZone(zone).uniqueCards() {
+			if !added[card.ID] {
+				added[card.ID] = true
+				result = append(result, card)
+			}
+		}
+	}
+	return result
+}
+
+// Returns the amount of cards in the zone of the deck
+func (d Deck) ZoneSize(zone Zone) int {
+	return d.getZone(zone).size()
+}
+
+// Returns true if the two cards can be commanders of the same deck
+//
+// Supports partner, partner with, friends forever, choose a background and doctor's companion
+func IsCommanderPair(a Card, b Card) bool {
+	return isCommanderPair(a, b) || isCommanderPair(b, a)
+}
+
+// Returns true if the second card can be the partner of the first one
+func isCommanderPair(a Card, b Card) bool {
+	textA := a.FullOracleText()
+	textB := b.FullOracleText()
+	switch {
+	case strings.Contains(textA, "Partner with "+b.Name):
+		return strings.Contains(textB, "Partner with "+a.Name)
+	case hasKeyword(a, "Partner") && !strings.Contains(textA, "Partner with"):
+		return hasKeyword(b, "Partner") && !strings.Contains(textB, "Partner with")
+	case hasKeyword(a, "Friends forever"):
+		return hasKeyword(b, "Friends forever")
+	case strings.Contains(textA, "Choose a Background"):
+		return strings.Contains(b.TypeLine, "Background")
+	case hasKeyword(a, "Doctor's companion"):
+		return strings.Contains(b.TypeLine, "Time Lord Doctor")
+	}
+	return false
+}
+
+// Returns true if the card has the keyword (case insensitive)
+func hasKeyword(card Card, keyword string) bool {
+	for _, k := range card.Keywords {
+		if strings.EqualFold(k, keyword) {
+			return true
+		}
+	}
+	return false
+}
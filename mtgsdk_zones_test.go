@@ -0,0 +1,117 @@
+package mtgsdk
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+var (
+	// A printing of Sol Ring used by the finish tests
+	finishTestCard = Card{ID: "sol-ring-c21", Name: "Sol Ring", Set: "c21", CollectorNumber: "263"}
+)
+
+// Returns the amounts of the finishes of the entries (finish -- amount)
+func finishAmounts(entries []DeckEntry) map[Finish]int {
+	result := map[Finish]int{}
+	for _, entry := range entries {
+		result[entry.Finish] += entry.Amount
+	}
+	return result
+}
+
+func TestDeckFinishesAreSeparate(t *testing.T) {
+	deck := CreateDeck("finishes")
+	deck.AddCardWithFinish(&finishTestCard, 1, FinishNonfoil)
+	deck.AddCardWithFinish(&finishTestCard, 1, FinishFoil)
+	deck.AddCardWithFinish(&finishTestCard, 2, FinishNonfoil)
+
+	expected := map[Finish]int{FinishNonfoil: 3, FinishFoil: 1}
+	if got := finishAmounts(deck.Entries()); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected the finishes %v, got %v", expected, got)
+	}
+	if count := deck.Count(finishTestCard.ID); count != 4 {
+		t.Fatalf("expected 4 copies, got %d", count)
+	}
+
+	var buffer bytes.Buffer
+	err := deck.Export(&buffer, FileFormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := buffer.String(); text != "3 Sol Ring (C21) 263\n1 Sol Ring (C21) 263 *F*" {
+		t.Fatalf("unexpected decklist %q", text)
+	}
+}
+
+func TestDeckFinishesMove(t *testing.T) {
+	deck := CreateDeck("finishes")
+	deck.AddCardWithFinish(&finishTestCard, 1, FinishFoil)
+	deck.AddCardWithFinish(&finishTestCard, 2, FinishNonfoil)
+
+	err := deck.MoveCard(finishTestCard.ID, ZoneMain, ZoneSideboard, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := finishAmounts(deck.ZoneEntries(ZoneSideboard)); !reflect.DeepEqual(got, map[Finish]int{FinishFoil: 1, FinishNonfoil: 1}) {
+		t.Fatalf("expected the moved copies to keep their finishes, got %v", got)
+	}
+	if got := finishAmounts(deck.Entries()); !reflect.DeepEqual(got, map[Finish]int{FinishNonfoil: 1}) {
+		t.Fatalf("expected 1 nonfoil copy in the main deck, got %v", got)
+	}
+}
+
+func TestDeckDocumentFinishes(t *testing.T) {
+	deck := CreateDeck("finishes")
+	deck.AddCardWithFinish(&finishTestCard, 1, FinishFoil)
+	deck.AddCardWithFinish(&finishTestCard, 1, FinishNonfoil)
+
+	doc := deck.Document(false)
+	if len(doc.Zones[ZoneMain]) != 2 {
+		t.Fatalf("expected 2 document entries, got %v", doc.Zones[ZoneMain])
+	}
+	read, err := doc.Deck()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := read.Entries(), deck.Entries(); len(got) != 2 || got[0].Finish != expected[0].Finish || got[1].Finish != expected[1].Finish {
+		t.Fatalf("expected the entries %v, got %v", expected, got)
+	}
+}
+
+func TestDeckEditsCountedZones(t *testing.T) {
+	commander := Card{ID: "atraxa", Name: "Atraxa, Praetors' Voice"}
+	deck := CreateDeck("commander")
+	deck.SetCommanders(commander)
+	deck.AddCardWithFinish(&finishTestCard, 1, FinishFoil)
+	deck.AddCardWithFinish(&finishTestCard, 2, FinishNonfoil)
+	deck.AddSideboardCard(&finishTestCard, 1)
+
+	err := deck.SetQuantity(commander.ID, 1)
+	if err != nil {
+		t.Fatalf("expected the commander to be in the deck, got %v", err)
+	}
+	err = deck.SetQuantity(finishTestCard.ID, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := finishAmounts(deck.Entries()); !reflect.DeepEqual(got, map[Finish]int{FinishFoil: 3}) {
+		t.Fatalf("expected 3 foil copies in the main deck, got %v", got)
+	}
+	if !deck.RemoveCard(commander.ID) {
+		t.Fatal("expected the commander to be removed")
+	}
+	if deck.Contains(commander.ID) || len(deck.GetCommanders()) != 0 {
+		t.Fatalf("expected no commander, got %v", deck.GetCommanders())
+	}
+	if deck.TotalCount() != 3 {
+		t.Fatalf("expected 3 cards in the deck, got %d", deck.TotalCount())
+	}
+	// the sideboard isn't part of the deck
+	if deck.CountInZone(ZoneSideboard, finishTestCard.ID) != 1 {
+		t.Fatal("expected the sideboard copy to stay")
+	}
+	if err := deck.SetQuantity("missing", 1); err == nil {
+		t.Fatal("expected an error for a card that isn't in the deck")
+	}
+}